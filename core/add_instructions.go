@@ -17,8 +17,9 @@ func AddReg16T1(instr FetchedInstr) DecodedInstr {
     return AddRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr AddRegT1) Execute(regs *Registers) {
-    AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegT1) Execute(regs *Registers, mem Memory) error {
+    AddRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
 }
 
 func (instr AddRegT1) String() string {
@@ -49,18 +50,20 @@ func AddReg16T2(instr FetchedInstr) DecodedInstr {
     return AddRegT2{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegT2) Execute(regs *Registers) {
+func (instr AddRegT2) Execute(regs *Registers, mem Memory) error {
     if instr.Rd == PC && regs.InITBlock() && !regs.LastInITBlock() {
         // UNPREDICTABLE
         // Raise exception (UsageFault?)
-        return
+        return nil
     } else if instr.Rd == PC && instr.Rm == PC {
         // UNPREDICTABLE
         // Raise exception (UsageFault?)
-        return
+        return nil
     }
 
-    AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+    AddRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+
+    return nil
 }
 
 func (instr AddRegT2) String() string {
@@ -83,8 +86,9 @@ func AddRegSP16T1(instr FetchedInstr) DecodedInstr {
     return AddRegSPT1{Rd: Rdm, Rm: Rdm, Rn: SP, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegSPT1) Execute(regs *Registers) {
-    AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegSPT1) Execute(regs *Registers, mem Memory) error {
+    AddRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
 }
 
 func (instr AddRegSPT1) String() string {
@@ -108,8 +112,9 @@ func AddRegSP16T2(instr FetchedInstr) DecodedInstr {
     return AddRegSPT2{Rd: SP, Rm: Rm, Rn: SP, Imm: 0, setflags: NEVER}
 }
 
-func (instr AddRegSPT2) Execute(regs *Registers) {
-    AddRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr AddRegSPT2) Execute(regs *Registers, mem Memory) error {
+    AddRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
 }
 
 func (instr AddRegSPT2) String() string {
@@ -131,8 +136,9 @@ func AddImm16T1(instr FetchedInstr) DecodedInstr {
     return AddImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AddImmT1) Execute(regs *Registers) {
+func (instr AddImmT1) Execute(regs *Registers, mem Memory) error {
     AddImmediate(regs, InstrFields(instr))
+    return nil
 }
 
 func (instr AddImmT1) String() string {
@@ -153,8 +159,9 @@ func AddImm16T2(instr FetchedInstr) DecodedInstr {
     return AddImmT2{Rd: Rdn, Rm: 0, Rn: Rdn, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AddImmT2) Execute(regs *Registers) {
+func (instr AddImmT2) Execute(regs *Registers, mem Memory) error {
     AddImmediate(regs, InstrFields(instr))
+    return nil
 }
 
 func (instr AddImmT2) String() string {
@@ -176,10 +183,758 @@ func SubReg16T1(instr FetchedInstr) DecodedInstr {
     return SubRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr SubRegT1) Execute(regs *Registers) {
-    SubRegister(regs, InstrFields(instr), Shift{function: LSL_C, amount: 0})
+func (instr SubRegT1) Execute(regs *Registers, mem Memory) error {
+    SubRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
 }
 
 func (instr SubRegT1) String() string {
     return fmt.Sprintf("subs %s, %s, %s", instr.Rd, instr.Rm, instr.Rn)
 }
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T1 */
+type SubImmT1 InstrFields
+
+func SubImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := uint32((raw_instr >> 6) & 0x7)
+
+    return SubImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: Imm, setflags: NOT_IT}
+}
+
+func (instr SubImmT1) Execute(regs *Registers, mem Memory) error {
+    SubImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr SubImmT1) String() string {
+    return fmt.Sprintf("subs %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T2 */
+type SubImmT2 InstrFields
+
+func SubImm16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Imm := uint32(raw_instr & 0xff)
+    Rdn := RegIndex((raw_instr >> 8) & 0x7)
+
+    return SubImmT2{Rd: Rdn, Rm: 0, Rn: Rdn, Imm: Imm, setflags: NOT_IT}
+}
+
+func (instr SubImmT2) Execute(regs *Registers, mem Memory) error {
+    SubImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr SubImmT2) String() string {
+    return fmt.Sprintf("subs %s, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T1 */
+type AddImmSPT1 InstrFields
+
+func AddImmSP16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex((raw_instr >> 8) & 0x7)
+    Imm := (raw_instr & 0xff) << 2
+
+    return AddImmSPT1{Rd: Rd, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AddImmSPT1) Execute(regs *Registers, mem Memory) error {
+    AddImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr AddImmSPT1) String() string {
+    return fmt.Sprintf("add %s, sp, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T2 */
+type AddImmSPT2 InstrFields
+
+func AddImmSP16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Imm := (raw_instr & 0x7f) << 2
+
+    return AddImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AddImmSPT2) Execute(regs *Registers, mem Memory) error {
+    AddImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr AddImmSPT2) String() string {
+    return fmt.Sprintf("add sp, #%d", instr.Imm)
+}
+
+/* SUB (SP minus immediate)
+ * ARM ARM A7.7.173
+ * Encoding T1 */
+type SubImmSPT1 InstrFields
+
+func SubImmSP16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Imm := (raw_instr & 0x7f) << 2
+
+    return SubImmSPT1{Rd: SP, Rm: 0, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr SubImmSPT1) Execute(regs *Registers, mem Memory) error {
+    SubImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr SubImmSPT1) String() string {
+    return fmt.Sprintf("sub sp, #%d", instr.Imm)
+}
+
+/* ADR
+ * ARM ARM A7.7.7
+ * Encoding T1 */
+type AdrT1 InstrFields
+
+func Adr16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex((raw_instr >> 8) & 0x7)
+    Imm := (raw_instr & 0xff) << 2
+
+    return AdrT1{Rd: Rd, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AdrT1) Execute(regs *Registers, mem Memory) error {
+    regs.SetR(instr.Rd, align(regs.Pc(), 4)+instr.Imm)
+    return nil
+}
+
+func (instr AdrT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr AdrT1) String() string {
+    return fmt.Sprintf("adr %s, #%d @ %#x", instr.Rd, instr.Imm, align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* ADR
+ * ARM ARM A7.7.7
+ * Encodings T2 and T3
+ * The subtracting encoding T2 stores the offset as its two's complement */
+type AdrT2 InstrFields
+
+func Adr32T2(instr FetchedInstr) DecodedInstr {
+    decoded := Adr32T3(instr).(AdrT2)
+    decoded.Imm = -decoded.Imm
+
+    return decoded
+}
+
+func Adr32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    i := (raw_instr >> 26) & 0x1
+    imm3 := (raw_instr >> 12) & 0x7
+    Rd := RegIndex((raw_instr >> 8) & 0xf)
+    imm8 := raw_instr & 0xff
+
+    Imm := (i << 11) | (imm3 << 8) | imm8
+
+    return AdrT2{Rd: Rd, Imm: Imm, setflags: NEVER}
+}
+
+func (instr AdrT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rd == SP || instr.Rd == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, align(regs.Pc(), 4)+instr.Imm)
+
+    return nil
+}
+
+func (instr AdrT2) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr AdrT2) String() string {
+    return fmt.Sprintf("adr.w %s, #%d @ %#x", instr.Rd, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* ADC - Add with Carry (register)
+ * ARM ARM A7.7.2
+ * Encoding T1 */
+type AdcRegT1 InstrFields
+
+func AdcReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return AdcRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AdcRegT1) Execute(regs *Registers, mem Memory) error {
+    AddCarryRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
+}
+
+func (instr AdcRegT1) String() string {
+    return fmt.Sprintf("adc%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* SBC - Subtract with Carry (register)
+ * ARM ARM A7.7.123
+ * Encoding T1 */
+type SbcRegT1 InstrFields
+
+func SbcReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return SbcRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr SbcRegT1) Execute(regs *Registers, mem Memory) error {
+    SubCarryRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
+}
+
+func (instr SbcRegT1) String() string {
+    return fmt.Sprintf("sbc%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* RSB - Reverse Subtract (immediate)
+ * ARM ARM A7.7.117
+ * Encoding T1 */
+type RsbImmT1 InstrFields
+
+func RsbImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+
+    return RsbImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr RsbImmT1) Execute(regs *Registers, mem Memory) error {
+    ReverseSubImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr RsbImmT1) String() string {
+    return fmt.Sprintf("rsb%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADD (immediate)
+ * ARM ARM A7.7.3
+ * Encoding T3 */
+type AddImmT3 InstrFields
+
+func AddImm32T3(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return CmnImm32T1(instr)
+    } else if Rn == SP {
+        return AddImmSP32T3(instr)
+    }
+
+    return AddImmT3{Rd: Rd, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr AddImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr AddImmT3) String() string {
+    return fmt.Sprintf("add%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T3 */
+type AddImmSPT3 InstrFields
+
+func AddImmSP32T3(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    return AddImmSPT3{Rd: Rd, Rm: 0, Rn: SP, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr AddImmSPT3) Execute(regs *Registers, mem Memory) error {
+    if instr.Rd == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr AddImmSPT3) String() string {
+    return fmt.Sprintf("add%s.w %s, sp, #%d", instr.setflags, instr.Rd, instr.Imm)
+}
+
+/* ADC - Add with Carry (immediate)
+ * ARM ARM A7.7.1
+ * Encoding T1 */
+type AdcImmT1 InstrFields
+
+func AdcImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    return AdcImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr AdcImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddCarryImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr AdcImmT1) String() string {
+    return fmt.Sprintf("adc%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SBC - Subtract with Carry (immediate)
+ * ARM ARM A7.7.122
+ * Encoding T1 */
+type SbcImmT1 InstrFields
+
+func SbcImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    return SbcImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr SbcImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubCarryImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr SbcImmT1) String() string {
+    return fmt.Sprintf("sbc%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T3 */
+type SubImmT3 InstrFields
+
+func SubImm32T3(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return CmpImm32T2(instr)
+    } else if Rn == SP {
+        return SubImmSP32T2(instr)
+    }
+
+    return SubImmT3{Rd: Rd, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr SubImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr SubImmT3) String() string {
+    return fmt.Sprintf("sub%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (SP minus immediate)
+ * ARM ARM A7.7.173
+ * Encoding T2 */
+type SubImmSPT2 InstrFields
+
+func SubImmSP32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    return SubImmSPT2{Rd: Rd, Rm: 0, Rn: SP, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr SubImmSPT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rd == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr SubImmSPT2) String() string {
+    return fmt.Sprintf("sub%s.w %s, sp, #%d", instr.setflags, instr.Rd, instr.Imm)
+}
+
+/* RSB - Reverse Subtract (immediate)
+ * ARM ARM A7.7.117
+ * Encoding T2 */
+type RsbImmT2 InstrFields
+
+func RsbImm32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    return RsbImmT2{Rd: Rd, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: setflags}
+}
+
+func (instr RsbImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    ReverseSubImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr RsbImmT2) String() string {
+    return fmt.Sprintf("rsb%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADD (immediate)
+ * ARM ARM A7.7.3
+ * Encoding T4 */
+type AddImmT4 InstrFields
+
+func AddImm32T4(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Adr32T3(instr)
+    } else if Rn == SP {
+        return AddImmSP32T4(instr)
+    }
+
+    return AddImmT4{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr AddImmT4) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr AddImmT4) String() string {
+    return fmt.Sprintf("addw %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ADD (SP plus immediate)
+ * ARM ARM A7.7.5
+ * Encoding T4 */
+type AddImmSPT4 InstrFields
+
+func AddImmSP32T4(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    return AddImmSPT4{Rd: Rd, Rm: 0, Rn: SP, Imm: imm12, setflags: NEVER}
+}
+
+func (instr AddImmSPT4) Execute(regs *Registers, mem Memory) error {
+    if instr.Rd == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr AddImmSPT4) String() string {
+    return fmt.Sprintf("addw %s, sp, #%d", instr.Rd, instr.Imm)
+}
+
+/* SUB (immediate)
+ * ARM ARM A7.7.171
+ * Encoding T4 */
+type SubImmT4 InstrFields
+
+func SubImm32T4(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Adr32T2(instr)
+    } else if Rn == SP {
+        return SubImmSP32T3(instr)
+    }
+
+    return SubImmT4{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr SubImmT4) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr SubImmT4) String() string {
+    return fmt.Sprintf("subw %s, %s, #%d", instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* SUB (SP minus immediate)
+ * ARM ARM A7.7.173
+ * Encoding T3 */
+type SubImmSPT3 InstrFields
+
+func SubImmSP32T3(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    return SubImmSPT3{Rd: Rd, Rm: 0, Rn: SP, Imm: imm12, setflags: NEVER}
+}
+
+func (instr SubImmSPT3) Execute(regs *Registers, mem Memory) error {
+    if instr.Rd == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr SubImmSPT3) String() string {
+    return fmt.Sprintf("subw %s, sp, #%d", instr.Rd, instr.Imm)
+}
+
+/* ADD (register)
+ * ARM ARM A7.7.4
+ * Encoding T3 */
+type AddRegT3 InstrFields
+
+func AddReg32T3(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return CmnReg32T2(instr)
+    } else if Rn == SP {
+        return AddRegSP32T3(instr)
+    }
+
+    return AddRegT3{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr AddRegT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr AddRegT3) String() string {
+    return fmt.Sprintf("add%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* ADD (SP plus register)
+ * ARM ARM A7.7.6
+ * Encoding T3 */
+type AddRegSPT3 InstrFields
+
+func AddRegSP32T3(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return AddRegSPT3{Rd: Rd, Rm: Rm, Rn: SP, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr AddRegSPT3) Execute(regs *Registers, mem Memory) error {
+    if !sp_shift_valid(instr.Rd, instr.Shift) || instr.Rd == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr AddRegSPT3) String() string {
+    return fmt.Sprintf("add%s.w %s, sp, %s%s", instr.setflags, instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* Whether the shift is allowed when writing SP, which is only LSL #0 to #3 */
+func sp_shift_valid(Rd RegIndex, shift Shift) bool {
+    return Rd != SP || (shift.srtype == SRTYPE_LSL && shift.amount <= 3)
+}
+
+/* ADC - Add with Carry (register)
+ * ARM ARM A7.7.2
+ * Encoding T2 */
+type AdcRegT2 InstrFields
+
+func AdcReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return AdcRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr AdcRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    AddCarryRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr AdcRegT2) String() string {
+    return fmt.Sprintf("adc%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* SBC - Subtract with Carry (register)
+ * ARM ARM A7.7.123
+ * Encoding T2 */
+type SbcRegT2 InstrFields
+
+func SbcReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return SbcRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr SbcRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubCarryRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr SbcRegT2) String() string {
+    return fmt.Sprintf("sbc%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* SUB (register)
+ * ARM ARM A7.7.172
+ * Encoding T2 */
+type SubRegT2 InstrFields
+
+func SubReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return CmpReg32T3(instr)
+    } else if Rn == SP {
+        return SubRegSP32T1(instr)
+    }
+
+    return SubRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr SubRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr SubRegT2) String() string {
+    return fmt.Sprintf("sub%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* SUB (SP minus register)
+ * ARM ARM A7.7.174
+ * Encoding T1 */
+type SubRegSPT1 InstrFields
+
+func SubRegSP32T1(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return SubRegSPT1{Rd: Rd, Rm: Rm, Rn: SP, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr SubRegSPT1) Execute(regs *Registers, mem Memory) error {
+    if !sp_shift_valid(instr.Rd, instr.Shift) || instr.Rd == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    SubRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr SubRegSPT1) String() string {
+    return fmt.Sprintf("sub%s.w %s, sp, %s%s", instr.setflags, instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* RSB - Reverse Subtract (register)
+ * ARM ARM A7.7.118
+ * Encoding T1 */
+type RsbRegT1 InstrFields
+
+func RsbReg32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return RsbRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr RsbRegT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    ReverseSubRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr RsbRegT1) String() string {
+    return fmt.Sprintf("rsb%s %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
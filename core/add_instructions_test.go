@@ -1,6 +1,7 @@
 package core
 
 import (
+    "fmt"
     "reflect"
     "testing"
 )
@@ -107,11 +108,11 @@ func TestExecuteAddRegT2(t *testing.T) {
         // add pc, r0
         {instr: AddRegT2{Rd: PC, Rm: 0, Rn: PC, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1000},
-            expected: Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1004}},
+            expected: Registers{r: GeneralRegs{4, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1004, branched: true}},
         // add pc, lr
         {instr: AddRegT2{Rd: PC, Rm: LR, Rn: PC, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 1000, lr: 2000},
-            expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 3000, lr: 2000}},
+            expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 3000, branched: true, lr: 2000}},
     }
 
     share_t = t
@@ -168,7 +169,7 @@ func TestExecuteAddRegSPT1(t *testing.T) {
         // add pc, sp, pc
         {instr: AddRegSPT1{Rd: PC, Rm: PC, Rn: SP, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000000, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}},
-            expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000004, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}}},
+            expected: Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, pc: 0x80000004, branched: true, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}}},
         // add sp, sp, sp
         {instr: AddRegSPT1{Rd: SP, Rm: SP, Rn: SP, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, sp: SPRegs{4, 0}, Control: Control{Spsel: MSP}},
@@ -401,3 +402,953 @@ func TestExecuteSubRegT1(t *testing.T) {
 
     test_execute(t, cases)
 }
+
+func TestIdentifyAdrT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xa101), instr_valid: true},  // adr r1, #4
+        {instr: FetchedInstr16(0xa3ff), instr_valid: true},  // adr r3, #1020
+        {instr: FetchedInstr16(0xa901), instr_valid: false}, // add r1, sp, #4
+        {instr: FetchedInstr16(0x4802), instr_valid: false}, // ldr r0, [pc, #8]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AdrT1{}))
+}
+
+func TestDecodeAdr16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // adr r1, #4
+        {instr: FetchedInstr16(0xa101), decoded: AdrT1{Rd: 1, Imm: 4, setflags: NEVER}},
+        // adr r3, #1020
+        {instr: FetchedInstr16(0xa3ff), decoded: AdrT1{Rd: 3, Imm: 1020, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Adr16T1)
+}
+
+func TestExecuteAdrT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // adr r1, #4, at a word-aligned address
+        {instr: AdrT1{Rd: 1, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{r: GeneralRegs{1: 0x108}, pc: 0x104}},
+        // adr r1, #4, at a halfword-aligned address
+        {instr: AdrT1{Rd: 1, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x106},
+            expected: Registers{r: GeneralRegs{1: 0x108}, pc: 0x106}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAdrT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf20f0008), instr_valid: true}, // adr.w r0, #8
+        {instr: FetchedInstr32(0xf60f72ff), instr_valid: true}, // adr.w r2, #4095
+        {instr: FetchedInstr32(0xf6af79ff), instr_valid: true}, // adr.w r9, #-4095
+        {instr: FetchedInstr16(0xa101), instr_valid: false},    // adr r1, #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AdrT2{}))
+}
+
+func TestDecodeAdr32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // adr.w r9, #-4095
+        {instr: FetchedInstr32(0xf6af79ff), decoded: AdrT2{Rd: 9, Imm: 0xfffff001, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Adr32T2)
+}
+
+func TestDecodeAdr32T3(t *testing.T) {
+    cases := []DecodeCase{
+        // adr.w r0, #8
+        {instr: FetchedInstr32(0xf20f0008), decoded: AdrT2{Rd: 0, Imm: 8, setflags: NEVER}},
+        // adr.w r2, #4095
+        {instr: FetchedInstr32(0xf60f72ff), decoded: AdrT2{Rd: 2, Imm: 4095, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Adr32T3)
+}
+
+func TestExecuteAdrT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // adr.w r9, #-4095
+        {instr: AdrT2{Rd: 9, Imm: 0xfffff001, setflags: NEVER},
+            regs:     Registers{pc: 0x2002},
+            expected: Registers{r: GeneralRegs{9: 0x1001}, pc: 0x2002}},
+        // adr.w r2, #4095
+        {instr: AdrT2{Rd: 2, Imm: 4095, setflags: NEVER},
+            regs:     Registers{pc: 0x2004},
+            expected: Registers{r: GeneralRegs{2: 0x3003}, pc: 0x2004}},
+        // adr.w sp, #8 (UNPREDICTABLE)
+        {instr: AdrT2{Rd: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{pc: 0x2004},
+            expected: Registers{pc: 0x2004}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAdcRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4148), instr_valid: true},  // adcs r0, r1
+        {instr: FetchedInstr16(0x419a), instr_valid: false}, // sbcs r2, r3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AdcRegT1{}))
+}
+
+func TestDecodeAdcReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // adcs r0, r1
+        {instr: FetchedInstr16(0x4148), decoded: AdcRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, AdcReg16T1)
+}
+
+func TestExecuteAdcRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // adcs r0, r1
+        {instr: AdcRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{2, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{5, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // adcs r0, r1, with carry in
+        {instr: AdcRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{2, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{6, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // adcs r0, r1, with carry out
+        {instr: AdcRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // adcs r0, r1, in an IT block
+        {instr: AdcRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySbcRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x419a), instr_valid: true},  // sbcs r2, r3
+        {instr: FetchedInstr16(0x4148), instr_valid: false}, // adcs r0, r1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SbcRegT1{}))
+}
+
+func TestDecodeSbcReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // sbcs r2, r3
+        {instr: FetchedInstr16(0x419a), decoded: SbcRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, SbcReg16T1)
+}
+
+func TestExecuteSbcRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // sbcs r2, r3, with carry (no borrow)
+        {instr: SbcRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // sbcs r2, r3, without carry (borrow)
+        {instr: SbcRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // sbcs r2, r3, result is negative
+        {instr: SbcRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyRsbImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4248), instr_valid: true},  // rsbs r0, r1, #0
+        {instr: FetchedInstr16(0x4223), instr_valid: false}, // tst r3, r4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(RsbImmT1{}))
+}
+
+func TestDecodeRsbImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // rsbs r0, r1, #0
+        {instr: FetchedInstr16(0x4248), decoded: RsbImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, RsbImm16T1)
+}
+
+func TestExecuteRsbImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // rsbs r0, r1, #0
+        {instr: RsbImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffffffff, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+        // rsbs r0, r1, #0, negating zero sets carry
+        {instr: RsbImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{7, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // rsbs r0, r1, #0, negating the most negative value overflows
+        {instr: RsbImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0x80000000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x80000000, 0x80000000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, V: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x1ed1), instr_valid: true},  // subs r1, r2, #3
+        {instr: FetchedInstr16(0x1fff), instr_valid: true},  // subs r7, r7, #7
+        {instr: FetchedInstr16(0x1c00), instr_valid: false}, // adds r0, r0, #0
+        {instr: FetchedInstr16(0x3dc8), instr_valid: false}, // subs r5, #200
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmT1{}))
+}
+
+func TestDecodeSubImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // subs r1, r2, #3
+        {instr: FetchedInstr16(0x1ed1), decoded: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT}},
+        // subs r7, r7, #7
+        {instr: FetchedInstr16(0x1fff), decoded: SubImmT1{Rd: 7, Rm: 0, Rn: 7, Imm: 7, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, SubImm16T1)
+}
+
+func TestExecuteSubImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // subs r1, r2, #3
+        {instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 2, 5, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // subs r1, r2, #3
+        {instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // subs r1, r2, #3, borrow
+        {instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xffffffff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+        // subs r1, r2, #3, in an IT block
+        {instr: SubImmT1{Rd: 1, Rm: 0, Rn: 2, Imm: 3, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 0xffffffff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x3dc8), instr_valid: true},  // subs r5, #200
+        {instr: FetchedInstr16(0x3000), instr_valid: false}, // adds r0, #0
+        {instr: FetchedInstr16(0x1ed1), instr_valid: false}, // subs r1, r2, #3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmT2{}))
+}
+
+func TestDecodeSubImm16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // subs r5, #200
+        {instr: FetchedInstr16(0x3dc8), decoded: SubImmT2{Rd: 5, Rm: 0, Rn: 5, Imm: 200, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, SubImm16T2)
+}
+
+func TestExecuteSubImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // subs r5, #200
+        {instr: SubImmT2{Rd: 5, Rm: 0, Rn: 5, Imm: 200, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 1000, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 800, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // subs r5, #200, signed overflow
+        {instr: SubImmT2{Rd: 5, Rm: 0, Rn: 5, Imm: 200, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000000, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x7fffff38, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAddImmSPT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xaaff), instr_valid: true},  // add r2, sp, #1020
+        {instr: FetchedInstr16(0xaf02), instr_valid: true},  // add r7, sp, #8
+        {instr: FetchedInstr16(0xa001), instr_valid: false}, // adr r0, #4
+        {instr: FetchedInstr16(0xb07f), instr_valid: false}, // add sp, #508
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmSPT1{}))
+}
+
+func TestDecodeAddImmSP16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // add r2, sp, #1020
+        {instr: FetchedInstr16(0xaaff), decoded: AddImmSPT1{Rd: 2, Rm: 0, Rn: SP, Imm: 1020, setflags: NEVER}},
+        // add r7, sp, #8
+        {instr: FetchedInstr16(0xaf02), decoded: AddImmSPT1{Rd: 7, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AddImmSP16T1)
+}
+
+func TestExecuteAddImmSPT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // add r7, sp, #8
+        {instr: AddImmSPT1{Rd: 7, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x20001008, 8, 9, 10, 11, 12}, sp: SPRegs{0x20001000, 0}}},
+        // add r7, sp, #8, flags are never set
+        {instr: AddImmSPT1{Rd: 7, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, sp: SPRegs{0xfffffff8, 0}, Apsr: Apsr{N: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0, 8, 9, 10, 11, 12}, sp: SPRegs{0xfffffff8, 0}, Apsr: Apsr{N: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAddImmSPT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xb07f), instr_valid: true},  // add sp, #508
+        {instr: FetchedInstr16(0xb000), instr_valid: true},  // add sp, #0
+        {instr: FetchedInstr16(0xb084), instr_valid: false}, // sub sp, #16
+        {instr: FetchedInstr16(0xb510), instr_valid: false}, // push {r4, lr}
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmSPT2{}))
+}
+
+func TestDecodeAddImmSP16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // add sp, #508
+        {instr: FetchedInstr16(0xb07f), decoded: AddImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: 508, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AddImmSP16T2)
+}
+
+func TestExecuteAddImmSPT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // add sp, #508
+        {instr: AddImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: 508, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{sp: SPRegs{0x200011fc, 0}}},
+        // add sp, #508, on the process stack
+        {instr: AddImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: 508, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0x20000800}, Control: Control{Spsel: PSP}},
+            expected: Registers{sp: SPRegs{0x20001000, 0x200009fc}, Control: Control{Spsel: PSP}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmSPT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xb084), instr_valid: true},  // sub sp, #16
+        {instr: FetchedInstr16(0xb07f), instr_valid: false}, // add sp, #508
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmSPT1{}))
+}
+
+func TestDecodeSubImmSP16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // sub sp, #16
+        {instr: FetchedInstr16(0xb084), decoded: SubImmSPT1{Rd: SP, Rm: 0, Rn: SP, Imm: 16, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, SubImmSP16T1)
+}
+
+func TestExecuteSubImmSPT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // sub sp, #16
+        {instr: SubImmSPT1{Rd: SP, Rm: 0, Rn: SP, Imm: 16, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}, Apsr: Apsr{Z: true}},
+            expected: Registers{sp: SPRegs{0x20000ff0, 0}, Apsr: Apsr{Z: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringAddSubImmediate(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr16
+        expected string
+    }{
+        {instr: 0x1ed1, expected: "subs r1, r2, #3"},
+        {instr: 0x3dc8, expected: "subs r5, #200"},
+        {instr: 0xaaff, expected: "add r2, sp, #1020"},
+        {instr: 0xb07f, expected: "add sp, #508"},
+        {instr: 0xb084, expected: "sub sp, #16"},
+        {instr: 0x2b80, expected: "cmp r3, #128"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes16.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
+
+func TestIdentifyAddImmT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf5015080), instr_valid: true},  // add.w r0, r1, #4096
+        {instr: FetchedInstr32(0xf1130201), instr_valid: true},  // adds.w r2, r3, #1
+        {instr: FetchedInstr32(0xf1150f01), instr_valid: false}, // cmn.w r5, #1
+        {instr: FetchedInstr32(0xf50d6480), instr_valid: false}, // add.w r4, sp, #0x400
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmT3{}))
+}
+
+func TestDecodeAddImm32T3(t *testing.T) {
+    cases := []DecodeCase{
+        // add.w r0, r1, #4096
+        {instr: FetchedInstr32(0xf5015080), decoded: AddImmT3{Rd: 0, Rm: 0, Rn: 1, Imm: 4096, setflags: NEVER}},
+        // adds.w r2, r3, #1
+        {instr: FetchedInstr32(0xf1130201), decoded: AddImmT3{Rd: 2, Rm: 0, Rn: 3, Imm: 1, setflags: ALWAYS}},
+        // cmn.w r5, #1
+        {instr: FetchedInstr32(0xf1150f01), decoded: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS}},
+        // add.w r4, sp, #0x400
+        {instr: FetchedInstr32(0xf50d6480), decoded: AddImmSPT3{Rd: 4, Rm: 0, Rn: SP, Imm: 0x400, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AddImm32T3)
+}
+
+func TestExecuteAddImmT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // add.w r0, r1, #4096
+        {instr: AddImmT3{Rd: 0, Rm: 0, Rn: 1, Imm: 4096, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xfffff000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xfffff000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // adds.w r2, r3, #1, flags are set even in an IT block
+        {instr: AddImmT3{Rd: 2, Rm: 0, Rn: 3, Imm: 1, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x7fffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x80000000, 0x7fffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, V: true}, Epsr: Epsr{IT: 0x08}}},
+        // add.w r0, pc, #4096 (UNPREDICTABLE)
+        {instr: AddImmT3{Rd: 0, Rm: 0, Rn: PC, Imm: 4096, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAddImmSPT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf50d6480), instr_valid: true},  // add.w r4, sp, #0x400
+        {instr: FetchedInstr32(0xf5015080), instr_valid: false}, // add.w r0, r1, #4096
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmSPT3{}))
+}
+
+func TestExecuteAddImmSPT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // add.w r4, sp, #0x400
+        {instr: AddImmSPT3{Rd: 4, Rm: 0, Rn: SP, Imm: 0x400, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{4: 0x20001400}, sp: SPRegs{0x20001000, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAdcImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf14706ff), instr_valid: true},  // adc r6, r7, #0xff
+        {instr: FetchedInstr32(0xf1790810), instr_valid: false}, // sbcs r8, r9, #0x10
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AdcImmT1{}))
+}
+
+func TestDecodeAdcImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // adc r6, r7, #0xff
+        {instr: FetchedInstr32(0xf14706ff), decoded: AdcImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0xff, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AdcImm32T1)
+}
+
+func TestExecuteAdcImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // adc r6, r7, #0xff
+        {instr: AdcImmT1{Rd: 6, Rm: 0, Rn: 7, Imm: 0xff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 1, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x101, 1, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySbcImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf1790810), instr_valid: true},  // sbcs r8, r9, #0x10
+        {instr: FetchedInstr32(0xf14706ff), instr_valid: false}, // adc r6, r7, #0xff
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SbcImmT1{}))
+}
+
+func TestDecodeSbcImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // sbcs r8, r9, #0x10
+        {instr: FetchedInstr32(0xf1790810), decoded: SbcImmT1{Rd: 8, Rm: 0, Rn: 9, Imm: 0x10, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, SbcImm32T1)
+}
+
+func TestExecuteSbcImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // sbcs r8, r9, #0x10, with borrow
+        {instr: SbcImmT1{Rd: 8, Rm: 0, Rn: 9, Imm: 0x10, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x11, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0, 0x11, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf1ab4a00), instr_valid: true},  // sub.w r10, r11, #0x80000000
+        {instr: FetchedInstr32(0xf1b30203), instr_valid: true},  // subs.w r2, r3, #3
+        {instr: FetchedInstr32(0xf5bc5f80), instr_valid: false}, // cmp.w r12, #0x1000
+        {instr: FetchedInstr32(0xf5ad7d80), instr_valid: false}, // sub.w sp, sp, #0x100
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmT3{}))
+}
+
+func TestDecodeSubImm32T3(t *testing.T) {
+    cases := []DecodeCase{
+        // sub.w r10, r11, #0x80000000
+        {instr: FetchedInstr32(0xf1ab4a00), decoded: SubImmT3{Rd: 10, Rm: 0, Rn: 11, Imm: 0x80000000, setflags: NEVER}},
+        // subs.w r2, r3, #3
+        {instr: FetchedInstr32(0xf1b30203), decoded: SubImmT3{Rd: 2, Rm: 0, Rn: 3, Imm: 3, setflags: ALWAYS}},
+        // sub.w sp, sp, #0x100
+        {instr: FetchedInstr32(0xf5ad7d80), decoded: SubImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: 0x100, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, SubImm32T3)
+}
+
+func TestExecuteSubImmT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // sub.w r10, r11, #0x80000000
+        {instr: SubImmT3{Rd: 10, Rm: 0, Rn: 11, Imm: 0x80000000, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 1, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x80000001, 1, 12}}},
+        // subs.w r2, r3, #3
+        {instr: SubImmT3{Rd: 2, Rm: 0, Rn: 3, Imm: 3, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // sub.w sp, r3, #3 (UNPREDICTABLE)
+        {instr: SubImmT3{Rd: SP, Rm: 0, Rn: 3, Imm: 3, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmSPT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf5ad7d80), instr_valid: true},  // sub.w sp, sp, #0x100
+        {instr: FetchedInstr32(0xf1ab4a00), instr_valid: false}, // sub.w r10, r11, #0x80000000
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmSPT2{}))
+}
+
+func TestExecuteSubImmSPT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // sub.w sp, sp, #0x100
+        {instr: SubImmSPT2{Rd: SP, Rm: 0, Rn: SP, Imm: 0x100, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{sp: SPRegs{0x20000f00, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyRsbImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf1c10000), instr_valid: true},  // rsb.w r0, r1, #0
+        {instr: FetchedInstr32(0xf1b30203), instr_valid: false}, // subs.w r2, r3, #3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(RsbImmT2{}))
+}
+
+func TestDecodeRsbImm32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // rsb.w r0, r1, #0
+        {instr: FetchedInstr32(0xf1c10000), decoded: RsbImmT2{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, RsbImm32T2)
+}
+
+func TestExecuteRsbImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // rsb.w r0, r1, #0
+        {instr: RsbImmT2{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xfffffffb, 5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringModifiedImmediate(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xf01312ab, expected: "ands.w r2, r3, #11206827"},
+        {instr: 0xf0144f00, expected: "tst.w r4, #2147483648"},
+        {instr: 0xf44f7b80, expected: "mov.w r11, #256"},
+        {instr: 0xf06f0101, expected: "mvn r1, #1"},
+        {instr: 0xf5015080, expected: "add.w r0, r1, #4096"},
+        {instr: 0xf5ad7d80, expected: "sub.w sp, sp, #256"},
+        {instr: 0xf1b30203, expected: "subs.w r2, r3, #3"},
+        {instr: 0xf5bc5f80, expected: "cmp.w r12, #4096"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes32.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
+
+func TestIdentifyAddImmT4(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf60170ff), instr_valid: true},  // addw r0, r1, #4095
+        {instr: FetchedInstr32(0xf20d0208), instr_valid: false}, // addw r2, sp, #8
+        {instr: FetchedInstr32(0xf20f0008), instr_valid: false}, // adr.w r0, #8
+        {instr: FetchedInstr32(0xf2a41323), instr_valid: false}, // subw r3, r4, #0x123
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmT4{}))
+}
+
+func TestDecodeAddImm32T4(t *testing.T) {
+    cases := []DecodeCase{
+        // addw r0, r1, #4095
+        {instr: FetchedInstr32(0xf60170ff), decoded: AddImmT4{Rd: 0, Rm: 0, Rn: 1, Imm: 4095, setflags: NEVER}},
+        // addw r2, sp, #8
+        {instr: FetchedInstr32(0xf20d0208), decoded: AddImmSPT4{Rd: 2, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER}},
+        // adr.w r0, #8
+        {instr: FetchedInstr32(0xf20f0008), decoded: AdrT2{Rd: 0, Imm: 8, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AddImm32T4)
+}
+
+func TestExecuteAddImmT4(t *testing.T) {
+    cases := []ExecuteCase{
+        // addw r0, r1, #4095, flags are never set
+        {instr: AddImmT4{Rd: 0, Rm: 0, Rn: 1, Imm: 4095, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xfffff001, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xfffff001, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // addw sp, r1, #4095 (UNPREDICTABLE)
+        {instr: AddImmT4{Rd: SP, Rm: 0, Rn: 1, Imm: 4095, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAddImmSPT4(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf20d0208), instr_valid: true},  // addw r2, sp, #8
+        {instr: FetchedInstr32(0xf60170ff), instr_valid: false}, // addw r0, r1, #4095
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddImmSPT4{}))
+}
+
+func TestExecuteAddImmSPT4(t *testing.T) {
+    cases := []ExecuteCase{
+        // addw r2, sp, #8
+        {instr: AddImmSPT4{Rd: 2, Rm: 0, Rn: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{2: 0x20001008}, sp: SPRegs{0x20001000, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmT4(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf2a41323), instr_valid: true},  // subw r3, r4, #0x123
+        {instr: FetchedInstr32(0xf6ad0d00), instr_valid: false}, // subw sp, sp, #0x800
+        {instr: FetchedInstr32(0xf6af79ff), instr_valid: false}, // adr.w r9, #-4095
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmT4{}))
+}
+
+func TestDecodeSubImm32T4(t *testing.T) {
+    cases := []DecodeCase{
+        // subw r3, r4, #0x123
+        {instr: FetchedInstr32(0xf2a41323), decoded: SubImmT4{Rd: 3, Rm: 0, Rn: 4, Imm: 0x123, setflags: NEVER}},
+        // subw sp, sp, #0x800
+        {instr: FetchedInstr32(0xf6ad0d00), decoded: SubImmSPT3{Rd: SP, Rm: 0, Rn: SP, Imm: 0x800, setflags: NEVER}},
+        // adr.w r9, #-4095
+        {instr: FetchedInstr32(0xf6af79ff), decoded: AdrT2{Rd: 9, Imm: 0xfffff001, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, SubImm32T4)
+}
+
+func TestExecuteSubImmT4(t *testing.T) {
+    cases := []ExecuteCase{
+        // subw r3, r4, #0x123
+        {instr: SubImmT4{Rd: 3, Rm: 0, Rn: 4, Imm: 0x123, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x100, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffffdd, 0x100, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubImmSPT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf6ad0d00), instr_valid: true},  // subw sp, sp, #0x800
+        {instr: FetchedInstr32(0xf2a41323), instr_valid: false}, // subw r3, r4, #0x123
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubImmSPT3{}))
+}
+
+func TestExecuteSubImmSPT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // subw sp, sp, #0x800
+        {instr: SubImmSPT3{Rd: SP, Rm: 0, Rn: SP, Imm: 0x800, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{sp: SPRegs{0x20000800, 0}}},
+        // subw pc, sp, #0x800 (UNPREDICTABLE)
+        {instr: SubImmSPT3{Rd: PC, Rm: 0, Rn: SP, Imm: 0x800, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0x20001000, 0}},
+            expected: Registers{sp: SPRegs{0x20001000, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAddRegT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xeb0100c2), instr_valid: true},  // add.w r0, r1, r2, lsl #3
+        {instr: FetchedInstr32(0xeb110012), instr_valid: true},  // adds.w r0, r1, r2, lsr #32
+        {instr: FetchedInstr32(0xeb130fa4), instr_valid: false}, // cmn.w r3, r4, asr #2
+        {instr: FetchedInstr32(0xeb0d0586), instr_valid: false}, // add.w r5, sp, r6, lsl #2
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AddRegT3{}))
+}
+
+func TestDecodeAddReg32T3(t *testing.T) {
+    cases := []DecodeCase{
+        // add.w r0, r1, r2, lsl #3
+        {instr: FetchedInstr32(0xeb0100c2), decoded: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 3}, setflags: NEVER}},
+        // adds.w r0, r1, r2, lsr #32
+        {instr: FetchedInstr32(0xeb110012), decoded: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSR, amount: 32}, setflags: ALWAYS}},
+        // cmn.w r3, r4, asr #2
+        {instr: FetchedInstr32(0xeb130fa4), decoded: CmnRegT2{Rd: 0, Rm: 4, Rn: 3, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 2}, setflags: ALWAYS}},
+        // add.w r5, sp, r6, lsl #2
+        {instr: FetchedInstr32(0xeb0d0586), decoded: AddRegSPT3{Rd: 5, Rm: 6, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, AddReg32T3)
+}
+
+func TestExecuteAddRegT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // add.w r0, r1, r2, lsl #3
+        {instr: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 3}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{17, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // adds.w r0, r1, r2, lsr #32
+        {instr: AddRegT3{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSR, amount: 32}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 0, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 0, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+        // add.w r0, r1, sp (UNPREDICTABLE)
+        {instr: AddRegT3{Rd: 0, Rm: SP, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteAddRegSPT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // add.w r5, sp, r6, lsl #2
+        {instr: AddRegSPT3{Rd: 5, Rm: 6, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{6: 4}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{5: 0x20001010, 6: 4}, sp: SPRegs{0x20001000, 0}}},
+        // add.w sp, sp, r6, lsl #2
+        {instr: AddRegSPT3{Rd: SP, Rm: 6, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{6: 4}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{6: 4}, sp: SPRegs{0x20001010, 0}}},
+        // add.w sp, sp, r6, lsl #4 (UNPREDICTABLE)
+        {instr: AddRegSPT3{Rd: SP, Rm: 6, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 4}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{6: 4}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{6: 4}, sp: SPRegs{0x20001000, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteAdcRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // adc.w r7, r8, r9, ror #8
+        {instr: AdcRegT2{Rd: 7, Rm: 9, Rn: 8, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 8}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x12, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x12000009, 8, 0x12, 10, 11, 12}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteSbcRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // sbcs.w r10, r11, r12, rrx
+        {instr: SbcRegT2{Rd: 10, Rm: 12, Rn: 11, Imm: 0, Shift: Shift{srtype: SRTYPE_RRX, amount: 1}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0x10, 0x2}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x8000000f, 0x10, 0x2}, Apsr: Apsr{N: true, V: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xeba170e2), instr_valid: true},  // sub.w r0, r1, r2, asr #31
+        {instr: FetchedInstr32(0xebb00f41), instr_valid: false}, // cmp.w r0, r1, lsl #1
+        {instr: FetchedInstr32(0xebad0203), instr_valid: false}, // sub.w r2, sp, r3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubRegT2{}))
+}
+
+func TestExecuteSubRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // sub.w r0, r1, r2, asr #31
+        {instr: SubRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 31}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 5, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{6, 5, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sub.w pc, r1, r2 (UNPREDICTABLE)
+        {instr: SubRegT2{Rd: PC, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 5, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 5, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySubRegSPT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xebad0203), instr_valid: true},  // sub.w r2, sp, r3
+        {instr: FetchedInstr32(0xeba170e2), instr_valid: false}, // sub.w r0, r1, r2, asr #31
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SubRegSPT1{}))
+}
+
+func TestExecuteSubRegSPT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // sub.w r2, sp, r3
+        {instr: SubRegSPT1{Rd: 2, Rm: 3, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0x10}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{2: 0x20000ff0, 3: 0x10}, sp: SPRegs{0x20001000, 0}}},
+        // sub.w sp, sp, r3, lsr #1 (UNPREDICTABLE)
+        {instr: SubRegSPT1{Rd: SP, Rm: 3, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSR, amount: 1}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0x10}, sp: SPRegs{0x20001000, 0}},
+            expected: Registers{r: GeneralRegs{3: 0x10}, sp: SPRegs{0x20001000, 0}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteRsbRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // rsb r4, r5, r6, lsl #16
+        {instr: RsbRegT1{Rd: 4, Rm: 6, Rn: 5, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 16}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 1, 1, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffff, 1, 1, 7, 8, 9, 10, 11, 12}}},
+        // rsbs r4, r5, r6
+        {instr: RsbRegT1{Rd: 4, Rm: 6, Rn: 5, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 6, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0, 6, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringShiftedRegister(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xeb0100c2, expected: "add.w r0, r1, r2, lsl #3"},
+        {instr: 0xeb110012, expected: "adds.w r0, r1, r2, lsr #32"},
+        {instr: 0xeb130fa4, expected: "cmn.w r3, r4, asr #2"},
+        {instr: 0xeb7b0a3c, expected: "sbcs.w r10, r11, r12, rrx"},
+        {instr: 0xebad0203, expected: "sub.w r2, sp, r3"},
+        {instr: 0xea612002, expected: "orn r0, r1, r2, lsl #8"},
+        {instr: 0xea980f49, expected: "teq r8, r9, lsl #1"},
+        {instr: 0xea5f0203, expected: "movs.w r2, r3"},
+        {instr: 0xea5f0617, expected: "lsrs.w r6, r7, #32"},
+        {instr: 0xea5f0c30, expected: "rrxs r12, r0"},
+        {instr: 0xeac14002, expected: "pkhbt r0, r1, r2, lsl #16"},
+        {instr: 0xeac40325, expected: "pkhtb r3, r4, r5, asr #32"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes32.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
@@ -26,7 +26,7 @@ func AddWithCarry(x uint32, y uint32, carry_in uint8) (uint32, uint8, uint8) {
 
 /* Perform addition instruction (reg), with shift, updating condition codes */
 func AddRegister(regs *Registers, instr InstrFields, shift Shift) {
-    shifted, _ := shift.Evaluate(regs.R(instr.Rm))
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
     result, carry, overflow := AddWithCarry(regs.R(instr.Rn), shifted, 0)
 
     add_update_condition_codes(regs, instr, result, carry, overflow)
@@ -41,12 +41,94 @@ func AddImmediate(regs *Registers, instr InstrFields) {
 
 /* Perform subtraction instruction (reg), with shift, updating condition codes */
 func SubRegister(regs *Registers, instr InstrFields, shift Shift) {
-    shifted, _ := shift.Evaluate(regs.R(instr.Rm))
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
     result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^shifted, 1)
 
     add_update_condition_codes(regs, instr, result, carry, overflow)
 }
 
+/* Perform subtraction instruction (imm), updating condition codes */
+func SubImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^instr.Imm, 1)
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform add with carry instruction (reg), with shift, updating condition codes */
+func AddCarryRegister(regs *Registers, instr InstrFields, shift Shift) {
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), shifted, booltou(regs.Apsr.C))
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform subtract with carry instruction (reg), with shift, updating condition codes */
+func SubCarryRegister(regs *Registers, instr InstrFields, shift Shift) {
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^shifted, booltou(regs.Apsr.C))
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform add with carry instruction (imm), updating condition codes */
+func AddCarryImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), instr.Imm, booltou(regs.Apsr.C))
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform subtract with carry instruction (imm), updating condition codes */
+func SubCarryImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^instr.Imm, booltou(regs.Apsr.C))
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform reverse subtraction instruction (imm), updating condition codes */
+func ReverseSubImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(^regs.R(instr.Rn), instr.Imm, 1)
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform reverse subtraction instruction (reg), with shift, updating condition codes */
+func ReverseSubRegister(regs *Registers, instr InstrFields, shift Shift) {
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result, carry, overflow := AddWithCarry(^regs.R(instr.Rn), shifted, 1)
+
+    add_update_condition_codes(regs, instr, result, carry, overflow)
+}
+
+/* Perform compare instruction (reg), with shift, always updating condition codes */
+func CompareRegister(regs *Registers, instr InstrFields, shift Shift) {
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^shifted, 1)
+
+    compare_update_condition_codes(regs, result, carry, overflow)
+}
+
+/* Perform compare instruction (imm), always updating condition codes */
+func CompareImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), ^instr.Imm, 1)
+
+    compare_update_condition_codes(regs, result, carry, overflow)
+}
+
+/* Perform compare negative instruction (imm), always updating condition codes */
+func CompareNegImmediate(regs *Registers, instr InstrFields) {
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), instr.Imm, 0)
+
+    compare_update_condition_codes(regs, result, carry, overflow)
+}
+
+/* Perform compare negative instruction (reg), with shift, always updating condition codes */
+func CompareNegRegister(regs *Registers, instr InstrFields, shift Shift) {
+    shifted, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result, carry, overflow := AddWithCarry(regs.R(instr.Rn), shifted, 0)
+
+    compare_update_condition_codes(regs, result, carry, overflow)
+}
+
 /* Update condition codes for ADD/SUB instruction */
 func add_update_condition_codes(regs *Registers, instr InstrFields, result uint32, carry uint8, overflow uint8) {
     if instr.Rd == PC {
@@ -61,3 +143,11 @@ func add_update_condition_codes(regs *Registers, instr InstrFields, result uint3
         }
     }
 }
+
+/* Update condition codes for CMP/CMN instruction, which discards the result */
+func compare_update_condition_codes(regs *Registers, result uint32, carry uint8, overflow uint8) {
+    regs.Apsr.N = (result & 0x80000000) != 0
+    regs.Apsr.Z = (result) == 0
+    regs.Apsr.C = utobool(carry)
+    regs.Apsr.V = utobool(overflow)
+}
@@ -3,15 +3,14 @@ package core
 type UnpredictableInstr InstrFields
 
 // Case to execute in the event of UNPREDICTABLE instruction behavior
-func (instr UnpredictableInstr) Execute(regs *Registers) {
+func (instr UnpredictableInstr) Execute(regs *Registers, mem Memory) error {
     // Do nothing, for now
-    return
+    return nil
 }
 
 type UndefinedInstr InstrFields
 
-// Placeholder UNDEFINED instruction
-func (instr UndefinedInstr) Execute(regs *Registers) {
-    // Do nothing, for now
-    return
+// UNDEFINED instruction
+func (instr UndefinedInstr) Execute(regs *Registers, mem Memory) error {
+    return UFSR_UNDEFINSTR
 }
@@ -0,0 +1,122 @@
+package core
+
+import "fmt"
+
+/* Fields shared by the bitfield encodings, with imm3:imm2 as the lsb and
+ * the bottom five bits as either the msb or the width minus 1 */
+func bitfield_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, lsb uint32, imm5 uint32) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    lsb = ((raw_instr>>12)&0x7)<<2 | (raw_instr>>6)&0x3
+    imm5 = raw_instr & 0x1f
+
+    return Rd, Rn, lsb, imm5
+}
+
+/* Width of the field from lsb to msb, or 0 if msb is below lsb */
+func bitfield_width(lsb uint32, msb uint32) uint32 {
+    if msb < lsb {
+        return 0
+    }
+    return msb - lsb + 1
+}
+
+/* BFC - Bit Field Clear
+ * ARM ARM A7.7.13 */
+type BfcT1 InstrFields
+
+func Bfc32T1(instr FetchedInstr) DecodedInstr {
+    Rd, _, lsb, msb := bitfield_fields(instr.Uint32())
+
+    return BfcT1{Rd: Rd, Imm: lsb, Width: bitfield_width(lsb, msb), setflags: NEVER}
+}
+
+func (instr BfcT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || !bitfield_valid(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitfieldInsert(regs, InstrFields(instr), 0)
+
+    return nil
+}
+
+func (instr BfcT1) String() string {
+    return fmt.Sprintf("bfc %s, #%d, #%d", instr.Rd, instr.Imm, instr.Width)
+}
+
+/* BFI - Bit Field Insert
+ * ARM ARM A7.7.14 */
+type BfiT1 InstrFields
+
+func Bfi32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, lsb, msb := bitfield_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Bfc32T1(instr)
+    }
+
+    return BfiT1{Rd: Rd, Rn: Rn, Imm: lsb, Width: bitfield_width(lsb, msb), setflags: NEVER}
+}
+
+func (instr BfiT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || !bitfield_valid(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitfieldInsert(regs, InstrFields(instr), regs.R(instr.Rn))
+
+    return nil
+}
+
+func (instr BfiT1) String() string {
+    return fmt.Sprintf("bfi %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Imm, instr.Width)
+}
+
+/* SBFX - Signed Bit Field Extract
+ * ARM ARM A7.7.124 */
+type SbfxT1 InstrFields
+
+func Sbfx32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, lsb, widthm1 := bitfield_fields(instr.Uint32())
+
+    return SbfxT1{Rd: Rd, Rn: Rn, Imm: lsb, Width: widthm1 + 1, setflags: NEVER}
+}
+
+func (instr SbfxT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || !bitfield_valid(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitfieldExtract(regs, InstrFields(instr), true)
+
+    return nil
+}
+
+func (instr SbfxT1) String() string {
+    return fmt.Sprintf("sbfx %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Imm, instr.Width)
+}
+
+/* UBFX - Unsigned Bit Field Extract
+ * ARM ARM A7.7.193 */
+type UbfxT1 InstrFields
+
+func Ubfx32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, lsb, widthm1 := bitfield_fields(instr.Uint32())
+
+    return UbfxT1{Rd: Rd, Rn: Rn, Imm: lsb, Width: widthm1 + 1, setflags: NEVER}
+}
+
+func (instr UbfxT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || !bitfield_valid(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitfieldExtract(regs, InstrFields(instr), false)
+
+    return nil
+}
+
+func (instr UbfxT1) String() string {
+    return fmt.Sprintf("ubfx %s, %s, #%d, #%d", instr.Rd, instr.Rn, instr.Imm, instr.Width)
+}
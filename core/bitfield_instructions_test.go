@@ -0,0 +1,208 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyBfi(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf361100b), instr_valid: true},  // bfi r0, r1, #4, #8
+        {instr: FetchedInstr32(0xf36a79df), instr_valid: true},  // bfi r9, r10, #31, #1
+        {instr: FetchedInstr32(0xf36f021f), instr_valid: false}, // bfc r2, #0, #32
+        {instr: FetchedInstr32(0xf3c47303), instr_valid: false}, // ubfx r3, r4, #28, #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BfiT1{}))
+}
+
+func TestDecodeBfi32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // bfi r0, r1, #4, #8
+        {instr: FetchedInstr32(0xf361100b), decoded: BfiT1{Rd: 0, Rm: 0, Rn: 1, Imm: 4, Width: 8, setflags: NEVER}},
+        // bfi r9, r10, #31, #1
+        {instr: FetchedInstr32(0xf36a79df), decoded: BfiT1{Rd: 9, Rm: 0, Rn: 10, Imm: 31, Width: 1, setflags: NEVER}},
+        // bfc r2, #0, #32
+        {instr: FetchedInstr32(0xf36f021f), decoded: BfcT1{Rd: 2, Rm: 0, Rn: 0, Imm: 0, Width: 32, setflags: NEVER}},
+        // bfi r0, r1, with msb 3 below lsb 4
+        {instr: FetchedInstr32(0xf3611003), decoded: BfiT1{Rd: 0, Rm: 0, Rn: 1, Imm: 4, Width: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Bfi32T1)
+}
+
+func TestExecuteBfi(t *testing.T) {
+    cases := []ExecuteCase{
+        // bfi r0, r1, #4, #8
+        {instr: BfiT1{Rd: 0, Rm: 0, Rn: 1, Imm: 4, Width: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 0x123, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xfffff23f, 0x123, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfi r9, r10, #31, #1
+        {instr: BfiT1{Rd: 9, Rm: 0, Rn: 10, Imm: 31, Width: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x7fffffff, 0xffffffff, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xffffffff, 0xffffffff, 11, 12}}},
+        // bfi r2, r3, #0, #32
+        {instr: BfiT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0, Width: 32, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x89abcdef, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x89abcdef, 0x89abcdef, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfi r0, r1, with msb below lsb (UNPREDICTABLE)
+        {instr: BfiT1{Rd: 0, Rm: 0, Rn: 1, Imm: 4, Width: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfi sp, r1, #0, #8 (UNPREDICTABLE)
+        {instr: BfiT1{Rd: SP, Rm: 0, Rn: 1, Imm: 0, Width: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfi r0, sp, #0, #8 (UNPREDICTABLE)
+        {instr: BfiT1{Rd: 0, Rm: 0, Rn: SP, Imm: 0, Width: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBfc(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf36f021f), instr_valid: true},  // bfc r2, #0, #32
+        {instr: FetchedInstr32(0xf361100b), instr_valid: false}, // bfi r0, r1, #4, #8
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BfcT1{}))
+}
+
+func TestExecuteBfc(t *testing.T) {
+    cases := []ExecuteCase{
+        // bfc r2, #0, #32
+        {instr: BfcT1{Rd: 2, Rm: 0, Rn: 0, Imm: 0, Width: 32, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfc r3, #28, #4
+        {instr: BfcT1{Rd: 3, Rm: 0, Rn: 0, Imm: 28, Width: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x0fffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfc r3, with msb below lsb (UNPREDICTABLE)
+        {instr: BfcT1{Rd: 3, Rm: 0, Rn: 0, Imm: 8, Width: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // bfc pc, #0, #1 (UNPREDICTABLE)
+        {instr: BfcT1{Rd: PC, Rm: 0, Rn: 0, Imm: 0, Width: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyUbfx(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf3c47303), instr_valid: true},  // ubfx r3, r4, #28, #4
+        {instr: FetchedInstr32(0xf3460507), instr_valid: false}, // sbfx r5, r6, #0, #8
+    }
+
+    test_identify(t, cases, reflect.TypeOf(UbfxT1{}))
+}
+
+func TestDecodeUbfx32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ubfx r3, r4, #28, #4
+        {instr: FetchedInstr32(0xf3c47303), decoded: UbfxT1{Rd: 3, Rm: 0, Rn: 4, Imm: 28, Width: 4, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Ubfx32T1)
+}
+
+func TestExecuteUbfx(t *testing.T) {
+    cases := []ExecuteCase{
+        // ubfx r3, r4, #28, #4
+        {instr: UbfxT1{Rd: 3, Rm: 0, Rn: 4, Imm: 28, Width: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xa0000000, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xa, 0xa0000000, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ubfx r0, r1, #0, #32
+        {instr: UbfxT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, Width: 32, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xfedcba98, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xfedcba98, 0xfedcba98, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ubfx r3, r4, #28, #5 (UNPREDICTABLE)
+        {instr: UbfxT1{Rd: 3, Rm: 0, Rn: 4, Imm: 28, Width: 5, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xa0000000, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xa0000000, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ubfx r3, pc, #0, #4 (UNPREDICTABLE)
+        {instr: UbfxT1{Rd: 3, Rm: 0, Rn: PC, Imm: 0, Width: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySbfx(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf3460507), instr_valid: true},  // sbfx r5, r6, #0, #8
+        {instr: FetchedInstr32(0xf3c47303), instr_valid: false}, // ubfx r3, r4, #28, #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SbfxT1{}))
+}
+
+func TestDecodeSbfx32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // sbfx r5, r6, #0, #8
+        {instr: FetchedInstr32(0xf3460507), decoded: SbfxT1{Rd: 5, Rm: 0, Rn: 6, Imm: 0, Width: 8, setflags: NEVER}},
+        // sbfx r0, r1, #16, #16
+        {instr: FetchedInstr32(0xf341400f), decoded: SbfxT1{Rd: 0, Rm: 0, Rn: 1, Imm: 16, Width: 16, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Sbfx32T1)
+}
+
+func TestExecuteSbfx(t *testing.T) {
+    cases := []ExecuteCase{
+        // sbfx r5, r6, #0, #8
+        {instr: SbfxT1{Rd: 5, Rm: 0, Rn: 6, Imm: 0, Width: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x1280, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffffff80, 0x1280, 7, 8, 9, 10, 11, 12}}},
+        // sbfx r0, r1, #16, #16
+        {instr: SbfxT1{Rd: 0, Rm: 0, Rn: 1, Imm: 16, Width: 16, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x7fff8000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x7fff, 0x7fff8000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sbfx r0, r1, #31, #1
+        {instr: SbfxT1{Rd: 0, Rm: 0, Rn: 1, Imm: 31, Width: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x80000000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffffffff, 0x80000000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sbfx r0, r1, #16, #17 (UNPREDICTABLE)
+        {instr: SbfxT1{Rd: 0, Rm: 0, Rn: 1, Imm: 16, Width: 17, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sbfx sp, r1, #0, #8 (UNPREDICTABLE)
+        {instr: SbfxT1{Rd: SP, Rm: 0, Rn: 1, Imm: 0, Width: 8, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringBitfield(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xf361100b, expected: "bfi r0, r1, #4, #8"},
+        {instr: 0xf36f021f, expected: "bfc r2, #0, #32"},
+        {instr: 0xf3c47303, expected: "ubfx r3, r4, #28, #4"},
+        {instr: 0xf3460507, expected: "sbfx r5, r6, #0, #8"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes32.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
@@ -0,0 +1,31 @@
+package core
+
+/* Mask of width bits starting at lsb */
+func bitfield_mask(lsb uint32, width uint32) uint32 {
+    return uint32((uint64(1)<<width)-1) << lsb
+}
+
+/* Perform bitfield extract instruction, sign extending the field if signed */
+func BitfieldExtract(regs *Registers, instr InstrFields, signed bool) {
+    field := regs.R(instr.Rn) >> instr.Imm
+    unused := 32 - instr.Width
+
+    if signed {
+        regs.SetR(instr.Rd, uint32(int32(field<<unused)>>unused))
+    } else {
+        regs.SetR(instr.Rd, (field<<unused)>>unused)
+    }
+}
+
+/* Perform bitfield insert instruction, inserting the bottom bits of value */
+func BitfieldInsert(regs *Registers, instr InstrFields, value uint32) {
+    mask := bitfield_mask(instr.Imm, instr.Width)
+    result := (regs.R(instr.Rd) &^ mask) | ((value << instr.Imm) & mask)
+
+    regs.SetR(instr.Rd, result)
+}
+
+/* Whether the field fits in a register, with a width of at least 1 */
+func bitfield_valid(instr InstrFields) bool {
+    return instr.Width != 0 && instr.Imm+instr.Width <= 32
+}
@@ -0,0 +1,288 @@
+package core
+
+import "fmt"
+
+/* B - Branch
+ * ARM ARM A7.7.12
+ * Encoding T1 */
+type BranchT1 InstrFields
+
+func Branch16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    /* Sign extend imm8:'0' */
+    Imm := uint32(int32(int8(raw_instr&0xff)) << 1)
+    Cond := Condition((raw_instr >> 8) & 0xf)
+
+    return BranchT1{Cond: Cond, Imm: Imm, setflags: NEVER}
+}
+
+func (instr BranchT1) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    if ConditionPassed(instr.Cond, regs.Apsr) {
+        regs.BranchWritePC(regs.Pc() + instr.Imm)
+    }
+
+    return nil
+}
+
+func (instr BranchT1) String() string {
+    return fmt.Sprintf("b%s #%d", instr.Cond, int32(instr.Imm))
+}
+
+/* B - Branch
+ * ARM ARM A7.7.12
+ * Encoding T2 */
+type BranchT2 InstrFields
+
+func Branch16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    /* Sign extend imm11:'0' */
+    Imm := uint32(int32(raw_instr<<21) >> 20)
+
+    return BranchT2{Cond: COND_AL, Imm: Imm, setflags: NEVER}
+}
+
+func (instr BranchT2) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.BranchWritePC(regs.Pc() + instr.Imm)
+
+    return nil
+}
+
+func (instr BranchT2) String() string {
+    return fmt.Sprintf("b #%d", int32(instr.Imm))
+}
+
+/* BL - Branch with Link
+ * ARM ARM A7.7.18 */
+type BranchLinkT1 InstrFields
+
+func BranchLink32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    S := (raw_instr >> 26) & 0x1
+    imm10 := (raw_instr >> 16) & 0x3ff
+    J1 := (raw_instr >> 13) & 0x1
+    J2 := (raw_instr >> 11) & 0x1
+    imm11 := raw_instr & 0x7ff
+
+    I1 := ^(J1 ^ S) & 0x1
+    I2 := ^(J2 ^ S) & 0x1
+
+    /* Sign extend S:I1:I2:imm10:imm11:'0' */
+    Imm := (S << 24) | (I1 << 23) | (I2 << 22) | (imm10 << 12) | (imm11 << 1)
+    Imm = uint32(int32(Imm<<7) >> 7)
+
+    return BranchLinkT1{Cond: COND_AL, Imm: Imm, setflags: NEVER}
+}
+
+func (instr BranchLinkT1) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    next_instr_addr := regs.Pc()
+    regs.SetR(LR, next_instr_addr|0x1)
+    regs.BranchWritePC(regs.Pc() + instr.Imm)
+
+    return nil
+}
+
+func (instr BranchLinkT1) String() string {
+    return fmt.Sprintf("bl #%d", int32(instr.Imm))
+}
+
+/* BX - Branch and Exchange
+ * ARM ARM A7.7.20 */
+type BranchExchange InstrFields
+
+func BranchExchange16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rm := RegIndex((raw_instr >> 3) & 0xf)
+
+    return BranchExchange{Rm: Rm, setflags: NEVER}
+}
+
+func (instr BranchExchange) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.BXWritePC(regs.R(instr.Rm))
+
+    return nil
+}
+
+func (instr BranchExchange) String() string {
+    return fmt.Sprintf("bx %s", instr.Rm)
+}
+
+/* BLX - Branch with Link and Exchange (register)
+ * ARM ARM A7.7.19 */
+type BranchLinkExchange InstrFields
+
+func BranchLinkExchange16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rm := RegIndex((raw_instr >> 3) & 0xf)
+
+    return BranchLinkExchange{Rm: Rm, setflags: NEVER}
+}
+
+func (instr BranchLinkExchange) Execute(regs *Registers, mem Memory) error {
+    if instr.Rm == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    target := regs.R(instr.Rm)
+    next_instr_addr := regs.Pc() - 2
+    regs.SetR(LR, next_instr_addr|0x1)
+    regs.BLXWritePC(target)
+
+    return nil
+}
+
+func (instr BranchLinkExchange) String() string {
+    return fmt.Sprintf("blx %s", instr.Rm)
+}
+
+/* CBZ - Compare and Branch on Zero
+ * ARM ARM A7.7.21 */
+type CompareBranchZero InstrFields
+
+/* Zero extend i:imm5:'0' */
+func compare_branch_imm(raw_instr uint32) uint32 {
+    return ((raw_instr>>9)&0x1)<<6 | ((raw_instr>>3)&0x1f)<<1
+}
+
+func CompareBranchZero16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex(raw_instr & 0x7)
+    Imm := compare_branch_imm(raw_instr)
+
+    return CompareBranchZero{Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr CompareBranchZero) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    if regs.R(instr.Rn) == 0 {
+        regs.BranchWritePC(regs.Pc() + instr.Imm)
+    }
+
+    return nil
+}
+
+func (instr CompareBranchZero) String() string {
+    return fmt.Sprintf("cbz %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* CBNZ - Compare and Branch on Nonzero
+ * ARM ARM A7.7.21 */
+type CompareBranchNonZero InstrFields
+
+func CompareBranchNonZero16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex(raw_instr & 0x7)
+    Imm := compare_branch_imm(raw_instr)
+
+    return CompareBranchNonZero{Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr CompareBranchNonZero) Execute(regs *Registers, mem Memory) error {
+    if regs.InITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    if regs.R(instr.Rn) != 0 {
+        regs.BranchWritePC(regs.Pc() + instr.Imm)
+    }
+
+    return nil
+}
+
+func (instr CompareBranchNonZero) String() string {
+    return fmt.Sprintf("cbnz %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* Branch forward by twice the unsigned entry of size bytes at index Rm in
+ * the table at Rn */
+func table_branch(regs *Registers, mem Memory, instr InstrFields, size uint32) error {
+    halfwords, err := MemRead(mem, regs.R(instr.Rn)+size*regs.R(instr.Rm), size)
+    if err != nil {
+        return err
+    }
+
+    regs.BranchWritePC(regs.Pc() + 2*halfwords)
+
+    return nil
+}
+
+/* TBB - Table Branch Byte
+ * ARM ARM A7.7.182 */
+type TableBranchByte InstrFields
+
+func TableBranchByte32(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+    Rm := RegIndex(raw_instr & 0xf)
+
+    return TableBranchByte{Rm: Rm, Rn: Rn, setflags: NEVER}
+}
+
+func (instr TableBranchByte) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return table_branch(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr TableBranchByte) String() string {
+    return fmt.Sprintf("tbb [%s, %s]", instr.Rn, instr.Rm)
+}
+
+/* TBH - Table Branch Halfword
+ * ARM ARM A7.7.182 */
+type TableBranchHalfword InstrFields
+
+func TableBranchHalfword32(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+    Rm := RegIndex(raw_instr & 0xf)
+
+    return TableBranchHalfword{Rm: Rm, Rn: Rn, setflags: NEVER}
+}
+
+func (instr TableBranchHalfword) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return table_branch(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr TableBranchHalfword) String() string {
+    return fmt.Sprintf("tbh [%s, %s, lsl #1]", instr.Rn, instr.Rm)
+}
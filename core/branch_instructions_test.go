@@ -0,0 +1,399 @@
+package core
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyBranchT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xd0fe), instr_valid: true},  // beq #-4
+        {instr: FetchedInstr16(0xd100), instr_valid: true},  // bne #0
+        {instr: FetchedInstr16(0xdcfb), instr_valid: true},  // bgt #-10
+        {instr: FetchedInstr16(0xdd7f), instr_valid: true},  // ble #254
+        {instr: FetchedInstr16(0xde00), instr_valid: false}, // udf #0
+        {instr: FetchedInstr16(0xdf00), instr_valid: false}, // svc #0
+        {instr: FetchedInstr16(0xe7fe), instr_valid: false}, // b #-4
+        {instr: FetchedInstr16(0x2000), instr_valid: false}, // mov r0, #0
+        {instr: FetchedInstr16(0xffff), instr_valid: false},
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BranchT1{}))
+}
+
+func TestDecodeBranch16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // beq #-4
+        {instr: FetchedInstr16(0xd0fe), decoded: BranchT1{Cond: COND_EQ, Imm: 0xfffffffc, setflags: NEVER}},
+        // bne #0
+        {instr: FetchedInstr16(0xd100), decoded: BranchT1{Cond: COND_NE, Imm: 0, setflags: NEVER}},
+        // bgt #-10
+        {instr: FetchedInstr16(0xdcfb), decoded: BranchT1{Cond: COND_GT, Imm: 0xfffffff6, setflags: NEVER}},
+        // ble #254
+        {instr: FetchedInstr16(0xdd7f), decoded: BranchT1{Cond: COND_LE, Imm: 254, setflags: NEVER}},
+        // bvs #-256
+        {instr: FetchedInstr16(0xd680), decoded: BranchT1{Cond: COND_VS, Imm: 0xffffff00, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Branch16T1)
+}
+
+func TestExecuteBranchT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // beq #-4, taken
+        {instr: BranchT1{Cond: COND_EQ, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Apsr: Apsr{Z: true}},
+            expected: Registers{pc: 0x100, branched: true, Apsr: Apsr{Z: true}}},
+        // beq #-4, not taken
+        {instr: BranchT1{Cond: COND_EQ, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Apsr: Apsr{Z: false}},
+            expected: Registers{pc: 0x104, Apsr: Apsr{Z: false}}},
+        // bgt #254
+        {instr: BranchT1{Cond: COND_GT, Imm: 254, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Apsr: Apsr{N: true, V: true}},
+            expected: Registers{pc: 0x202, branched: true, Apsr: Apsr{N: true, V: true}}},
+        // bgt #254, in an IT block (UNPREDICTABLE)
+        {instr: BranchT1{Cond: COND_GT, Imm: 254, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{pc: 0x104, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBranchT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xe7fe), instr_valid: true},  // b #-4
+        {instr: FetchedInstr16(0xe000), instr_valid: true},  // b #0
+        {instr: FetchedInstr16(0xe3ff), instr_valid: true},  // b #2046
+        {instr: FetchedInstr16(0xe400), instr_valid: true},  // b #-2048
+        {instr: FetchedInstr16(0xd0fe), instr_valid: false}, // beq #-4
+        {instr: FetchedInstr16(0xe800), instr_valid: false}, // 32-bit prefix
+        {instr: FetchedInstr16(0xffff), instr_valid: false},
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BranchT2{}))
+}
+
+func TestDecodeBranch16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // b #-4
+        {instr: FetchedInstr16(0xe7fe), decoded: BranchT2{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER}},
+        // b #0
+        {instr: FetchedInstr16(0xe000), decoded: BranchT2{Cond: COND_AL, Imm: 0, setflags: NEVER}},
+        // b #2046
+        {instr: FetchedInstr16(0xe3ff), decoded: BranchT2{Cond: COND_AL, Imm: 2046, setflags: NEVER}},
+        // b #-2048
+        {instr: FetchedInstr16(0xe400), decoded: BranchT2{Cond: COND_AL, Imm: 0xfffff800, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Branch16T2)
+}
+
+func TestExecuteBranchT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // b #-4
+        {instr: BranchT2{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{pc: 0x100, branched: true}},
+        // b #0
+        {instr: BranchT2{Cond: COND_AL, Imm: 0, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{pc: 0x104, branched: true}},
+        // b #-2048, last in IT block
+        {instr: BranchT2{Cond: COND_AL, Imm: 0xfffff800, setflags: NEVER},
+            regs:     Registers{pc: 0x1004, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{pc: 0x804, branched: true, Epsr: Epsr{IT: 0x08}}},
+        // b #-2048, not last in IT block (UNPREDICTABLE)
+        {instr: BranchT2{Cond: COND_AL, Imm: 0xfffff800, setflags: NEVER},
+            regs:     Registers{pc: 0x1004, Epsr: Epsr{IT: 0x04}},
+            expected: Registers{pc: 0x1004, Epsr: Epsr{IT: 0x04}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBranchLinkT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf7fffffe), instr_valid: true},  // bl #-4
+        {instr: FetchedInstr32(0xf100f804), instr_valid: true},  // bl #1048584
+        {instr: FetchedInstr32(0xf6fffff6), instr_valid: true},  // bl #-1048596
+        {instr: FetchedInstr32(0xf7ffbffe), instr_valid: false}, // b.w #-4
+        {instr: FetchedInstr16(0x4770), instr_valid: false},     // bx lr
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BranchLinkT1{}))
+}
+
+func TestDecodeBranchLink32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // bl #-4
+        {instr: FetchedInstr32(0xf7fffffe), decoded: BranchLinkT1{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER}},
+        // bl #1048584
+        {instr: FetchedInstr32(0xf100f804), decoded: BranchLinkT1{Cond: COND_AL, Imm: 0x100008, setflags: NEVER}},
+        // bl #-1048596
+        {instr: FetchedInstr32(0xf6fffff6), decoded: BranchLinkT1{Cond: COND_AL, Imm: 0xffefffec, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, BranchLink32T1)
+}
+
+func TestExecuteBranchLinkT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // bl #-4
+        {instr: BranchLinkT1{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{pc: 0x100, lr: 0x105, branched: true}},
+        // bl #1048584
+        {instr: BranchLinkT1{Cond: COND_AL, Imm: 0x100008, setflags: NEVER},
+            regs:     Registers{pc: 0x4},
+            expected: Registers{pc: 0x10000c, lr: 0x5, branched: true}},
+        // bl #-4, last in an IT block
+        {instr: BranchLinkT1{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{pc: 0x100, lr: 0x105, branched: true, Epsr: Epsr{IT: 0x08}}},
+        // bl #-4, not last in an IT block (UNPREDICTABLE)
+        {instr: BranchLinkT1{Cond: COND_AL, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{IT: 0x04}},
+            expected: Registers{pc: 0x104, Epsr: Epsr{IT: 0x04}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBranchExchange(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4770), instr_valid: true},  // bx lr
+        {instr: FetchedInstr16(0x4718), instr_valid: true},  // bx r3
+        {instr: FetchedInstr16(0x4798), instr_valid: false}, // blx r3
+        {instr: FetchedInstr16(0x4670), instr_valid: false}, // mov r0, lr
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BranchExchange{}))
+}
+
+func TestDecodeBranchExchange16(t *testing.T) {
+    cases := []DecodeCase{
+        // bx lr
+        {instr: FetchedInstr16(0x4770), decoded: BranchExchange{Rm: LR, setflags: NEVER}},
+        // bx r3
+        {instr: FetchedInstr16(0x4718), decoded: BranchExchange{Rm: 3, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, BranchExchange16)
+}
+
+func TestExecuteBranchExchange(t *testing.T) {
+    cases := []ExecuteCase{
+        // bx lr
+        {instr: BranchExchange{Rm: LR, setflags: NEVER},
+            regs:     Registers{pc: 0x104, lr: 0x201, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0x200, lr: 0x201, branched: true, Epsr: Epsr{T: true}}},
+        // bx r3, to an even address
+        {instr: BranchExchange{Rm: 3, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0x200}, pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{3: 0x200}, pc: 0x200, branched: true, Epsr: Epsr{T: false}}},
+        // bx lr, returning from an exception
+        {instr: BranchExchange{Rm: LR, setflags: NEVER},
+            regs:     Registers{pc: 0x104, lr: 0xfffffff9, Mode: MODE_HANDLER, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0xfffffff9, lr: 0xfffffff9, branched: true, exc_ret: true, Mode: MODE_HANDLER, Epsr: Epsr{T: true}}},
+        // bx lr, not last in an IT block (UNPREDICTABLE)
+        {instr: BranchExchange{Rm: LR, setflags: NEVER},
+            regs:     Registers{pc: 0x104, lr: 0x201, Epsr: Epsr{T: true, IT: 0x04}},
+            expected: Registers{pc: 0x104, lr: 0x201, Epsr: Epsr{T: true, IT: 0x04}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBranchLinkExchange(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4798), instr_valid: true},  // blx r3
+        {instr: FetchedInstr16(0x47f0), instr_valid: true},  // blx lr
+        {instr: FetchedInstr16(0x4770), instr_valid: false}, // bx lr
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BranchLinkExchange{}))
+}
+
+func TestDecodeBranchLinkExchange16(t *testing.T) {
+    cases := []DecodeCase{
+        // blx r3
+        {instr: FetchedInstr16(0x4798), decoded: BranchLinkExchange{Rm: 3, setflags: NEVER}},
+        // blx lr
+        {instr: FetchedInstr16(0x47f0), decoded: BranchLinkExchange{Rm: LR, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, BranchLinkExchange16)
+}
+
+func TestExecuteBranchLinkExchange(t *testing.T) {
+    cases := []ExecuteCase{
+        // blx r3
+        {instr: BranchLinkExchange{Rm: 3, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0x301}, pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{3: 0x301}, pc: 0x300, lr: 0x103, branched: true, Epsr: Epsr{T: true}}},
+        // blx lr
+        {instr: BranchLinkExchange{Rm: LR, setflags: NEVER},
+            regs:     Registers{pc: 0x104, lr: 0x301, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0x300, lr: 0x103, branched: true, Epsr: Epsr{T: true}}},
+        // blx r3, to an even address
+        {instr: BranchLinkExchange{Rm: 3, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0x300}, pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{3: 0x300}, pc: 0x300, lr: 0x103, branched: true, Epsr: Epsr{T: false}}},
+        // blx pc (UNPREDICTABLE)
+        {instr: BranchLinkExchange{Rm: PC, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0x104, Epsr: Epsr{T: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCompareBranchZero(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xb110), instr_valid: true},  // cbz r0, #4
+        {instr: FetchedInstr16(0xb103), instr_valid: true},  // cbz r3, #0
+        {instr: FetchedInstr16(0xb3ff), instr_valid: true},  // cbz r7, #126
+        {instr: FetchedInstr16(0xbbff), instr_valid: false}, // cbnz r7, #126
+        {instr: FetchedInstr16(0xbf08), instr_valid: false}, // it eq
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CompareBranchZero{}))
+}
+
+func TestDecodeCompareBranchZero16(t *testing.T) {
+    cases := []DecodeCase{
+        // cbz r0, #4
+        {instr: FetchedInstr16(0xb110), decoded: CompareBranchZero{Rn: 0, Imm: 4, setflags: NEVER}},
+        // cbz r3, #0
+        {instr: FetchedInstr16(0xb103), decoded: CompareBranchZero{Rn: 3, Imm: 0, setflags: NEVER}},
+        // cbz r7, #126
+        {instr: FetchedInstr16(0xb3ff), decoded: CompareBranchZero{Rn: 7, Imm: 126, setflags: NEVER}},
+        // cbz r1, #64
+        {instr: FetchedInstr16(0xb301), decoded: CompareBranchZero{Rn: 1, Imm: 64, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, CompareBranchZero16)
+}
+
+func TestExecuteCompareBranchZero(t *testing.T) {
+    cases := []ExecuteCase{
+        // cbz r0, #4, taken
+        {instr: CompareBranchZero{Rn: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{pc: 0x108, branched: true}},
+        // cbz r0, #4, not taken
+        {instr: CompareBranchZero{Rn: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{1}, pc: 0x104},
+            expected: Registers{r: GeneralRegs{1}, pc: 0x104}},
+        // cbz r0, #4, flags are unaffected
+        {instr: CompareBranchZero{Rn: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Apsr: Apsr{N: true}},
+            expected: Registers{pc: 0x108, branched: true, Apsr: Apsr{N: true}}},
+        // cbz r0, #4, in an IT block (UNPREDICTABLE)
+        {instr: CompareBranchZero{Rn: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{pc: 0x104, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCompareBranchNonZero(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xbbff), instr_valid: true},  // cbnz r7, #126
+        {instr: FetchedInstr16(0xb909), instr_valid: true},  // cbnz r1, #2
+        {instr: FetchedInstr16(0xb110), instr_valid: false}, // cbz r0, #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CompareBranchNonZero{}))
+}
+
+func TestDecodeCompareBranchNonZero16(t *testing.T) {
+    cases := []DecodeCase{
+        // cbnz r7, #126
+        {instr: FetchedInstr16(0xbbff), decoded: CompareBranchNonZero{Rn: 7, Imm: 126, setflags: NEVER}},
+        // cbnz r1, #2
+        {instr: FetchedInstr16(0xb909), decoded: CompareBranchNonZero{Rn: 1, Imm: 2, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, CompareBranchNonZero16)
+}
+
+func TestExecuteCompareBranchNonZero(t *testing.T) {
+    cases := []ExecuteCase{
+        // cbnz r7, #126, taken
+        {instr: CompareBranchNonZero{Rn: 7, Imm: 126, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{7: 0x80000000}, pc: 0x104},
+            expected: Registers{r: GeneralRegs{7: 0x80000000}, pc: 0x182, branched: true}},
+        // cbnz r7, #126, not taken
+        {instr: CompareBranchNonZero{Rn: 7, Imm: 126, setflags: NEVER},
+            regs:     Registers{pc: 0x104},
+            expected: Registers{pc: 0x104}},
+        // cbnz r7, #126, last in an IT block (UNPREDICTABLE)
+        {instr: CompareBranchNonZero{Rn: 7, Imm: 126, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{7: 1}, pc: 0x104, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{7: 1}, pc: 0x104, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyTableBranch(t *testing.T) {
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8dff000), instr_valid: true},  // tbb [pc, r0]
+        {instr: FetchedInstr32(0xe8d1f002), instr_valid: true},  // tbb [r1, r2]
+        {instr: FetchedInstr32(0xe8d1f012), instr_valid: false}, // tbh [r1, r2, lsl #1]
+    }, reflect.TypeOf(TableBranchByte{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8d1f012), instr_valid: true},  // tbh [r1, r2, lsl #1]
+        {instr: FetchedInstr32(0xe8dff000), instr_valid: false}, // tbb [pc, r0]
+    }, reflect.TypeOf(TableBranchHalfword{}))
+}
+
+func TestDecodeTableBranch32(t *testing.T) {
+    test_decode(t, []DecodeCase{
+        // tbb [pc, r0]
+        {instr: FetchedInstr32(0xe8dff000), decoded: TableBranchByte{Rm: 0, Rn: PC, setflags: NEVER}},
+    }, TableBranchByte32)
+
+    test_decode(t, []DecodeCase{
+        // tbh [r1, r2, lsl #1]
+        {instr: FetchedInstr32(0xe8d1f012), decoded: TableBranchHalfword{Rm: 2, Rn: 1, setflags: NEVER}},
+    }, TableBranchHalfword32)
+}
+
+func TestExecuteTableBranch(t *testing.T) {
+    cases := []ExecuteCase{
+        // tbb [pc, r0]
+        {instr: TableBranchByte{Rm: 0, Rn: PC, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{1}, pc: 0x4},
+            expected: Registers{r: GeneralRegs{1}, pc: 0x1fe, branched: true},
+            mem:      Block{0, 0, 0, 0, 0x02, 0xfd}},
+        // tbh [r1, r2, lsl #1]
+        {instr: TableBranchHalfword{Rm: 2, Rn: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 2, 1}, pc: 0x100},
+            expected: Registers{r: GeneralRegs{0, 2, 1}, pc: 0x10102, branched: true},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x80}},
+        // tbh [r1, r2, lsl #1], outside of memory
+        {instr: TableBranchHalfword{Rm: 2, Rn: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 2, 2}, pc: 0x100},
+            expected: Registers{r: GeneralRegs{0, 2, 2}, pc: 0x100},
+            mem:      Block{0, 0, 0, 0, 0, 0},
+            err:      BusError{Addr: 6, Size: 2, Write: false}},
+        // tbb [sp, r0] (UNPREDICTABLE)
+        {instr: TableBranchByte{Rm: 0, Rn: SP, setflags: NEVER},
+            regs:     Registers{pc: 0x4},
+            expected: Registers{pc: 0x4},
+            mem:      Block{0x10}},
+        // tbb [pc, r0], not last in an IT block (UNPREDICTABLE)
+        {instr: TableBranchByte{Rm: 0, Rn: PC, setflags: NEVER},
+            regs:     Registers{pc: 0x4, Epsr: Epsr{IT: 0x04}},
+            expected: Registers{pc: 0x4, Epsr: Epsr{IT: 0x04}},
+            mem:      Block{0, 0, 0, 0, 0x10}},
+    }
+
+    test_execute(t, cases)
+}
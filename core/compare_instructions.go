@@ -0,0 +1,204 @@
+package core
+
+import "fmt"
+
+/* CMP - Compare (immediate)
+ * ARM ARM A7.7.27
+ * Encoding T1 */
+type CmpImmT1 InstrFields
+
+func CmpImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Imm := uint32(raw_instr & 0xff)
+    Rn := RegIndex((raw_instr >> 8) & 0x7)
+
+    return CmpImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: Imm, setflags: ALWAYS}
+}
+
+func (instr CmpImmT1) Execute(regs *Registers, mem Memory) error {
+    CompareImmediate(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr CmpImmT1) String() string {
+    return fmt.Sprintf("cmp %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* CMP - Compare (register)
+ * ARM ARM A7.7.28
+ * Encoding T1 */
+type CmpRegT1 InstrFields
+
+func CmpReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return CmpRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmpRegT1) Execute(regs *Registers, mem Memory) error {
+    CompareRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
+}
+
+func (instr CmpRegT1) String() string {
+    return fmt.Sprintf("cmp %s, %s", instr.Rn, instr.Rm)
+}
+
+/* CMP - Compare (register)
+ * ARM ARM A7.7.28
+ * Encoding T2 */
+type CmpRegT2 InstrFields
+
+func CmpReg16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    rn := uint8(raw_instr & 0x7)
+    N := uint8((raw_instr >> 7) & 0x1)
+    Rn := RegIndex((N << 3) | rn)
+    Rm := RegIndex((raw_instr >> 3) & 0xf)
+
+    return CmpRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmpRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn < 8 && instr.Rm < 8 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rn == PC || instr.Rm == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    CompareRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+
+    return nil
+}
+
+func (instr CmpRegT2) String() string {
+    return fmt.Sprintf("cmp %s, %s", instr.Rn, instr.Rm)
+}
+
+/* CMN - Compare Negative (register)
+ * ARM ARM A7.7.26
+ * Encoding T1 */
+type CmnRegT1 InstrFields
+
+func CmnReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return CmnRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr CmnRegT1) Execute(regs *Registers, mem Memory) error {
+    CompareNegRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0})
+    return nil
+}
+
+func (instr CmnRegT1) String() string {
+    return fmt.Sprintf("cmn %s, %s", instr.Rn, instr.Rm)
+}
+
+/* CMP - Compare (immediate)
+ * ARM ARM A7.7.27
+ * Encoding T2 */
+type CmpImmT2 InstrFields
+
+func CmpImm32T2(instr FetchedInstr) DecodedInstr {
+    _, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    return CmpImmT2{Rd: 0, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: ALWAYS}
+}
+
+func (instr CmpImmT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    CompareImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr CmpImmT2) String() string {
+    return fmt.Sprintf("cmp.w %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* CMN - Compare Negative (immediate)
+ * ARM ARM A7.7.25
+ * Encoding T1 */
+type CmnImmT1 InstrFields
+
+func CmnImm32T1(instr FetchedInstr) DecodedInstr {
+    _, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+
+    return CmnImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: ThumbExpandImm(imm12), setflags: ALWAYS}
+}
+
+func (instr CmnImmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    CompareNegImmediate(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr CmnImmT1) String() string {
+    return fmt.Sprintf("cmn.w %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* CMP - Compare (register)
+ * ARM ARM A7.7.28
+ * Encoding T3 */
+type CmpRegT3 InstrFields
+
+func CmpReg32T3(instr FetchedInstr) DecodedInstr {
+    _, Rn, Rm, shift, _ := shifted_reg_fields(instr.Uint32())
+
+    return CmpRegT3{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: ALWAYS}
+}
+
+func (instr CmpRegT3) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    CompareRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr CmpRegT3) String() string {
+    return fmt.Sprintf("cmp.w %s, %s%s", instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* CMN - Compare Negative (register)
+ * ARM ARM A7.7.26
+ * Encoding T2 */
+type CmnRegT2 InstrFields
+
+func CmnReg32T2(instr FetchedInstr) DecodedInstr {
+    _, Rn, Rm, shift, _ := shifted_reg_fields(instr.Uint32())
+
+    return CmnRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: ALWAYS}
+}
+
+func (instr CmnRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    CompareNegRegister(regs, InstrFields(instr), instr.Shift)
+
+    return nil
+}
+
+func (instr CmnRegT2) String() string {
+    return fmt.Sprintf("cmn.w %s, %s%s", instr.Rn, instr.Rm, instr.Shift)
+}
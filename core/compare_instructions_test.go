@@ -0,0 +1,261 @@
+package core
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyCmpImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x2b80), instr_valid: true},  // cmp r3, #128
+        {instr: FetchedInstr16(0x2800), instr_valid: true},  // cmp r0, #0
+        {instr: FetchedInstr16(0x2000), instr_valid: false}, // movs r0, #0
+        {instr: FetchedInstr16(0x429a), instr_valid: false}, // cmp r2, r3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmpImmT1{}))
+}
+
+func TestDecodeCmpImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // cmp r3, #128
+        {instr: FetchedInstr16(0x2b80), decoded: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 128, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmpImm16T1)
+}
+
+func TestExecuteCmpImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmp r3, #128, equal
+        {instr: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 128, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 128, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 128, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // cmp r3, #128, lower
+        {instr: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 128, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+        // cmp r3, #128, in an IT block
+        {instr: CmpImmT1{Rd: 0, Rm: 0, Rn: 3, Imm: 128, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmpRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x429a), instr_valid: true},  // cmp r2, r3
+        {instr: FetchedInstr16(0x42ec), instr_valid: false}, // cmn r4, r5
+        {instr: FetchedInstr16(0x4588), instr_valid: false}, // cmp r8, r1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmpRegT1{}))
+}
+
+func TestDecodeCmpReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // cmp r2, r3
+        {instr: FetchedInstr16(0x429a), decoded: CmpRegT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmpReg16T1)
+}
+
+func TestExecuteCmpRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmp r2, r3, equal
+        {instr: CmpRegT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // cmp r2, r3, lower
+        {instr: CmpRegT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+        // cmp r2, r3, signed overflow
+        {instr: CmpRegT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 0x80000000, 1, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x80000000, 1, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}}},
+        // cmp r2, r3, in an IT block
+        {instr: CmpRegT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmpRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4588), instr_valid: true},  // cmp r8, r1
+        {instr: FetchedInstr16(0x4549), instr_valid: true},  // cmp r1, r9
+        {instr: FetchedInstr16(0x45f5), instr_valid: true},  // cmp sp, lr
+        {instr: FetchedInstr16(0x429a), instr_valid: false}, // cmp r2, r3
+        {instr: FetchedInstr16(0x4488), instr_valid: false}, // add r8, r1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmpRegT2{}))
+}
+
+func TestDecodeCmpReg16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // cmp r8, r1
+        {instr: FetchedInstr16(0x4588), decoded: CmpRegT2{Rd: 0, Rm: 1, Rn: 8, Imm: 0, setflags: ALWAYS}},
+        // cmp r1, r9
+        {instr: FetchedInstr16(0x4549), decoded: CmpRegT2{Rd: 0, Rm: 9, Rn: 1, Imm: 0, setflags: ALWAYS}},
+        // cmp sp, lr
+        {instr: FetchedInstr16(0x45f5), decoded: CmpRegT2{Rd: 0, Rm: LR, Rn: SP, Imm: 0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmpReg16T2)
+}
+
+func TestExecuteCmpRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmp r8, r1
+        {instr: CmpRegT2{Rd: 0, Rm: 1, Rn: 8, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // cmp r1, r2 (UNPREDICTABLE)
+        {instr: CmpRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // cmp r8, pc (UNPREDICTABLE)
+        {instr: CmpRegT2{Rd: 0, Rm: PC, Rn: 8, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmnRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x42ec), instr_valid: true},  // cmn r4, r5
+        {instr: FetchedInstr16(0x429a), instr_valid: false}, // cmp r2, r3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmnRegT1{}))
+}
+
+func TestDecodeCmnReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // cmn r4, r5
+        {instr: FetchedInstr16(0x42ec), decoded: CmnRegT1{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmnReg16T1)
+}
+
+func TestExecuteCmnRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmn r4, r5, sum is zero
+        {instr: CmnRegT1{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 1, 0xffffffff, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 1, 0xffffffff, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // cmn r4, r5, signed overflow
+        {instr: CmnRegT1{Rd: 0, Rm: 5, Rn: 4, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x7fffffff, 1, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x7fffffff, 1, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, V: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmpImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf5bc5f80), instr_valid: true},  // cmp.w r12, #0x1000
+        {instr: FetchedInstr32(0xf1b30203), instr_valid: false}, // subs.w r2, r3, #3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmpImmT2{}))
+}
+
+func TestDecodeCmpImm32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // cmp.w r12, #0x1000
+        {instr: FetchedInstr32(0xf5bc5f80), decoded: CmpImmT2{Rd: 0, Rm: 0, Rn: 12, Imm: 0x1000, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmpImm32T2)
+}
+
+func TestExecuteCmpImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmp.w r12, #0x1000
+        {instr: CmpImmT2{Rd: 0, Rm: 0, Rn: 12, Imm: 0x1000, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x1000}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x1000}, Apsr: Apsr{Z: true, C: true}}},
+        // cmp.w pc, #0x1000 (UNPREDICTABLE)
+        {instr: CmpImmT2{Rd: 0, Rm: 0, Rn: PC, Imm: 0x1000, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmnImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf1150f01), instr_valid: true},  // cmn.w r5, #1
+        {instr: FetchedInstr32(0xf1130201), instr_valid: false}, // adds.w r2, r3, #1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmnImmT1{}))
+}
+
+func TestDecodeCmnImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // cmn.w r5, #1
+        {instr: FetchedInstr32(0xf1150f01), decoded: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, CmnImm32T1)
+}
+
+func TestExecuteCmnImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmn.w r5, #1
+        {instr: CmnImmT1{Rd: 0, Rm: 0, Rn: 5, Imm: 1, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffffffff, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffffffff, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyCmpRegT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xebb00f41), instr_valid: true},  // cmp.w r0, r1, lsl #1
+        {instr: FetchedInstr32(0xeba170e2), instr_valid: false}, // sub.w r0, r1, r2, asr #31
+    }
+
+    test_identify(t, cases, reflect.TypeOf(CmpRegT3{}))
+}
+
+func TestExecuteCmpRegT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmp.w r0, r1, lsl #1
+        {instr: CmpRegT3{Rd: 0, Rm: 1, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 1}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{4, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{4, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // cmp.w r0, pc (UNPREDICTABLE)
+        {instr: CmpRegT3{Rd: 0, Rm: PC, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{4, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{4, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteCmnRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // cmn.w r3, r4, asr #2
+        {instr: CmnRegT2{Rd: 0, Rm: 4, Rn: 3, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 2}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 1, 0xfffffffc, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 1, 0xfffffffc, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
@@ -0,0 +1,64 @@
+package core
+
+/* Condition codes
+ * ARMv7-M ARM A7.3 */
+type Condition uint8
+
+const (
+    COND_EQ Condition = iota // Equal
+    COND_NE                  // Not equal
+    COND_CS                  // Carry set
+    COND_CC                  // Carry clear
+    COND_MI                  // Minus, negative
+    COND_PL                  // Plus, positive or zero
+    COND_VS                  // Overflow
+    COND_VC                  // No overflow
+    COND_HI                  // Unsigned higher
+    COND_LS                  // Unsigned lower or same
+    COND_GE                  // Signed greater than or equal
+    COND_LT                  // Signed less than
+    COND_GT                  // Signed greater than
+    COND_LE                  // Signed less than or equal
+    COND_AL                  // Always
+)
+
+var condition_names = [16]string{
+    "eq", "ne", "cs", "cc", "mi", "pl", "vs", "vc",
+    "hi", "ls", "ge", "lt", "gt", "le", "", "",
+}
+
+func (cond Condition) String() string {
+    return condition_names[cond&0xf]
+}
+
+/* Whether the APSR flags satisfy cond
+ * ARMv7-M ARM A7.3.1 */
+func ConditionPassed(cond Condition, apsr Apsr) bool {
+    var result bool
+
+    switch (cond >> 1) & 0x7 {
+    case 0x0: // EQ or NE
+        result = apsr.Z
+    case 0x1: // CS or CC
+        result = apsr.C
+    case 0x2: // MI or PL
+        result = apsr.N
+    case 0x3: // VS or VC
+        result = apsr.V
+    case 0x4: // HI or LS
+        result = apsr.C && !apsr.Z
+    case 0x5: // GE or LT
+        result = apsr.N == apsr.V
+    case 0x6: // GT or LE
+        result = apsr.N == apsr.V && !apsr.Z
+    case 0x7: // AL
+        result = true
+    }
+
+    /* Odd conditions are the inverse of the even ones, except for AL */
+    if (cond&0x1) != 0 && cond != 0xf {
+        result = !result
+    }
+
+    return result
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+    "testing"
+)
+
+func TestConditionPassed(t *testing.T) {
+    none := Apsr{}
+    n := Apsr{N: true}
+    z := Apsr{Z: true}
+    c := Apsr{C: true}
+    v := Apsr{V: true}
+    nv := Apsr{N: true, V: true}
+    cz := Apsr{C: true, Z: true}
+
+    cases := []struct {
+        cond   Condition
+        apsr   Apsr
+        passed bool
+    }{
+        {cond: COND_EQ, apsr: z, passed: true},
+        {cond: COND_EQ, apsr: none, passed: false},
+        {cond: COND_NE, apsr: z, passed: false},
+        {cond: COND_NE, apsr: none, passed: true},
+        {cond: COND_CS, apsr: c, passed: true},
+        {cond: COND_CS, apsr: none, passed: false},
+        {cond: COND_CC, apsr: c, passed: false},
+        {cond: COND_CC, apsr: none, passed: true},
+        {cond: COND_MI, apsr: n, passed: true},
+        {cond: COND_MI, apsr: none, passed: false},
+        {cond: COND_PL, apsr: n, passed: false},
+        {cond: COND_PL, apsr: none, passed: true},
+        {cond: COND_VS, apsr: v, passed: true},
+        {cond: COND_VS, apsr: none, passed: false},
+        {cond: COND_VC, apsr: v, passed: false},
+        {cond: COND_VC, apsr: none, passed: true},
+        {cond: COND_HI, apsr: c, passed: true},
+        {cond: COND_HI, apsr: cz, passed: false},
+        {cond: COND_HI, apsr: none, passed: false},
+        {cond: COND_LS, apsr: c, passed: false},
+        {cond: COND_LS, apsr: cz, passed: true},
+        {cond: COND_LS, apsr: none, passed: true},
+        {cond: COND_GE, apsr: none, passed: true},
+        {cond: COND_GE, apsr: nv, passed: true},
+        {cond: COND_GE, apsr: n, passed: false},
+        {cond: COND_LT, apsr: v, passed: true},
+        {cond: COND_LT, apsr: nv, passed: false},
+        {cond: COND_GT, apsr: nv, passed: true},
+        {cond: COND_GT, apsr: Apsr{N: true, V: true, Z: true}, passed: false},
+        {cond: COND_GT, apsr: n, passed: false},
+        {cond: COND_LE, apsr: z, passed: true},
+        {cond: COND_LE, apsr: v, passed: true},
+        {cond: COND_LE, apsr: none, passed: false},
+        {cond: COND_AL, apsr: none, passed: true},
+        {cond: COND_AL, apsr: Apsr{N: true, Z: true, C: true, V: true}, passed: true},
+        {cond: Condition(0xf), apsr: none, passed: true},
+    }
+
+    for _, test := range cases {
+        if ConditionPassed(test.cond, test.apsr) != test.passed {
+            t.Errorf("ConditionPassed(%d, %+v) != %v", test.cond, test.apsr, test.passed)
+        }
+    }
+}
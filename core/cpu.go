@@ -0,0 +1,159 @@
+package core
+
+/* Address of the vector table out of reset
+ * ARMv7-M ARM B3.2.5 */
+const VECTOR_TABLE = 0x00000000
+
+/* Called with each instruction just before it is executed */
+type TraceFunc func(addr uint32, fetched FetchedInstr, instr DecodedInstr)
+
+type CPU struct {
+    Regs   Registers
+    Scs    SystemControl
+    Trace  TraceFunc
+    Mem    Memory // System bus, outside of the processor
+    active [NUM_EXCEPTIONS]bool
+}
+
+func NewCPU(mem Memory) *CPU {
+    cpu := &CPU{Mem: mem}
+    cpu.Scs.Reset()
+
+    return cpu
+}
+
+/* Take a reset, loading the stack pointer and entry point from the vector table
+ * ARMv7-M ARM B1.5.5 */
+func (cpu *CPU) Reset() error {
+    sp_main, err := cpu.Mem.Read32(VECTOR_TABLE)
+    if err != nil {
+        return err
+    }
+
+    reset_vector, err := cpu.Mem.Read32(VECTOR_TABLE + 4)
+    if err != nil {
+        return err
+    }
+
+    cpu.Regs = Registers{}
+    cpu.Scs.Reset()
+    cpu.active = [NUM_EXCEPTIONS]bool{}
+
+    cpu.Regs.sp[MSP] = sp_main &^ 0x3
+    cpu.Regs.lr = 0xffffffff // Illegal exception return value
+    cpu.Regs.Mode = MODE_THREAD
+    cpu.Regs.Control = Control{Npriv: false, Spsel: MSP, Fpca: false}
+    cpu.Regs.Epsr.T = (reset_vector & 0x1) != 0
+    cpu.Regs.pc = reset_vector &^ 0x1
+
+    return nil
+}
+
+/* Fetch a single halfword of instruction stream */
+func (cpu *CPU) fetch16(addr uint32) (FetchedInstr16, error) {
+    halfword, err := cpu.Mem.Read16(addr)
+
+    return FetchedInstr16(halfword), err
+}
+
+/* Fetch the instruction at addr, which may be one or two halfwords long
+ * ARMv7-M ARM A5.1 */
+func (cpu *CPU) Fetch(addr uint32) (FetchedInstr, DecodedInstr, error) {
+    upper, err := cpu.fetch16(addr)
+    if err != nil {
+        return nil, nil, err
+    }
+
+    instr, err := upper.Decode()
+    if err != ErrIncompleteInstruction {
+        return upper, Locate(instr, addr), err
+    }
+
+    lower, err := cpu.fetch16(addr + 2)
+    if err != nil {
+        return upper, nil, err
+    }
+
+    fetched := upper.Extend(lower)
+    instr, err = fetched.Decode()
+
+    return fetched, Locate(instr, addr), err
+}
+
+/* Execute a single instruction, advancing PC past it unless it branched.
+ *
+ * While an instruction executes, PC holds its address plus 4, which is
+ * the value read from PC in Thumb state.  Between instructions, PC holds
+ * the address of the next instruction to execute.
+ *
+ * If the instruction faults, the fault exception is taken and Step returns
+ * nil, so the next Step runs its handler.  The fault is returned only if it
+ * cannot be taken, along with any error that is not an architectural fault. */
+func (cpu *CPU) Step() error {
+    addr := cpu.Regs.pc
+
+    /* There is no ARM state to switch to */
+    if !cpu.Regs.Epsr.T {
+        return cpu.raise(UFSR_INVSTATE, addr, false)
+    }
+
+    /* Undefined instructions decode to UndefinedInstr, which raises
+     * the fault only if it is executed */
+    fetched, instr, err := cpu.Fetch(addr)
+    if err != nil && err != ErrUndefinedInstruction {
+        return cpu.raise(err, addr, true)
+    }
+
+    if cpu.Trace != nil {
+        cpu.Trace(addr, fetched, instr)
+    }
+
+    cpu.Regs.pc = addr + 4
+    cpu.Regs.branched = false
+    cpu.Regs.exc_ret = false
+
+    /* Instructions in an IT block whose condition fails are skipped */
+    if ConditionPassed(cpu.Regs.CurrentCond(), cpu.Regs.Apsr) {
+        err := instr.Execute(&cpu.Regs, processor_bus{cpu: cpu})
+
+        /* Divide by zero gives 0 unless CCR.DIV_0_TRP makes it fault */
+        if div, ok := err.(DivideByZero); ok {
+            if (cpu.Scs.Ccr & CCR_DIV_0_TRP) != 0 {
+                err = UFSR_DIVBYZERO
+            } else {
+                cpu.Regs.SetR(div.Rd, 0)
+                err = nil
+            }
+        }
+
+        if err != nil {
+            /* Leave PC pointing at the instruction that failed */
+            cpu.Regs.pc = addr
+            return cpu.raise(err, addr, false)
+        }
+    }
+
+    /* IT sets up ITSTATE for the following instructions, everything
+     * else moves it along */
+    if _, ok := instr.(IfThen); !ok {
+        cpu.Regs.ITAdvance()
+    }
+
+    if !cpu.Regs.branched {
+        cpu.Regs.pc = addr + fetched.Size()
+    } else if cpu.Regs.exc_ret {
+        return cpu.ExceptionReturn(cpu.Regs.pc)
+    }
+
+    return nil
+}
+
+/* Execute instructions, taking any faults, until the processor stops on a
+ * fault that could not be taken or an error that is not a fault */
+func (cpu *CPU) Run() error {
+    for {
+        if err := cpu.Step(); err != nil {
+            return err
+        }
+    }
+}
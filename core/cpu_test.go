@@ -0,0 +1,325 @@
+package core
+
+import (
+    "testing"
+)
+
+/* Assemble halfwords into a little-endian memory image */
+func image16(halfwords ...uint16) Block {
+    b := make(Block, 0, 2*len(halfwords))
+
+    for _, h := range halfwords {
+        b = append(b, byte(h), byte(h>>8))
+    }
+
+    return b
+}
+
+/* CPU executing in Thumb state from address 0, without a reset */
+func thumb_cpu(mem Memory) *CPU {
+    cpu := NewCPU(mem)
+    cpu.Regs.Epsr.T = true
+
+    return cpu
+}
+
+func TestStepAdvancesPC(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x2010, // movs r0, #0x10
+        0x0840, // lsrs r0, r0, #1
+        0x4081, // lsls r1, r1, r0
+    ))
+
+    expected := []uint32{2, 4, 6}
+
+    for _, pc := range expected {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+
+        if cpu.Regs.Pc() != pc {
+            t.Errorf("PC = %#x, expected %#x", cpu.Regs.Pc(), pc)
+        }
+    }
+
+    if cpu.Regs.R(0) != 8 {
+        t.Errorf("r0 = %#x, expected 8", cpu.Regs.R(0))
+    }
+
+    /* The fetch fault cannot be taken, because there is no HardFault
+     * vector to read either */
+    if err := cpu.Step(); err != (BusError{Addr: 4 * EXC_HARD_FAULT, Size: 4, Write: false}) {
+        t.Errorf("Step past end of memory: %v", err)
+    }
+
+    if cpu.Regs.Pc() != 6 || cpu.Scs.Cfsr != CFSR_IBUSERR || cpu.Scs.Hfsr != HFSR_FORCED|HFSR_VECTTBL {
+        t.Errorf("PC = %#x, CFSR = %#x, HFSR = %#x", cpu.Regs.Pc(), cpu.Scs.Cfsr, cpu.Scs.Hfsr)
+    }
+}
+
+func TestStepReadPC(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x2002, // movs r0, #2
+        0x4487, // add pc, r0
+        0x2101, // movs r1, #1 (skipped)
+        0x2201, // movs r2, #1 (skipped)
+        0x2301, // movs r3, #1
+    ))
+
+    /* add pc, r0 reads PC as its own address plus 4, so it branches to 2 + 4 + 2 */
+    for i := 0; i < 3; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if cpu.Regs.R(1) != 0 || cpu.Regs.R(2) != 0 || cpu.Regs.R(3) != 1 {
+        t.Errorf("Wrong instructions executed:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Regs.Pc() != 10 {
+        t.Errorf("PC = %#x, expected 0xa", cpu.Regs.Pc())
+    }
+}
+
+func TestStepBranchToNextWord(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x46f7, // mov pc, lr
+        0x2101, // movs r1, #1
+    ))
+
+    /* A branch to the instruction's own address plus 4 must not be
+     * mistaken for an instruction that left PC alone */
+    cpu.Regs.SetR(LR, 4)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.Pc() != 4 {
+        t.Errorf("PC = %#x, expected 0x4", cpu.Regs.Pc())
+    }
+}
+
+func TestFetchWordInstr(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0xf000, 0x0000,
+        0x2000,
+        0xf000,
+    ))
+
+    fetched, _, _ := cpu.Fetch(0)
+    if fetched != FetchedInstr32(0xf0000000) {
+        t.Errorf("fetched: %#v, expected 32-bit instruction", fetched)
+    }
+
+    fetched, instr, err := cpu.Fetch(4)
+    if fetched != FetchedInstr16(0x2000) || err != nil {
+        t.Errorf("fetched: %#v (%#v, %v), expected 16-bit instruction", fetched, instr, err)
+    }
+
+    /* The second halfword falls outside of memory */
+    _, _, err = cpu.Fetch(6)
+    if err != (BusError{Addr: 8, Size: 2, Write: false}) {
+        t.Errorf("err: %v, expected bus error", err)
+    }
+}
+
+func TestReset(t *testing.T) {
+    bus := NewBus()
+    bus.Load(FLASH_BASE, []byte{
+        0x03, 0x80, 0x00, 0x20, // Initial SP: 0x20008003
+        0x09, 0x00, 0x00, 0x00, // Reset vector: 0x9
+    })
+
+    cpu := NewCPU(bus)
+    cpu.Regs.SetR(0, 0xdead)
+    cpu.Regs.Mode = MODE_HANDLER
+    cpu.Regs.Control.Npriv = true
+
+    if err := cpu.Reset(); err != nil {
+        t.Fatalf("Reset: %v", err)
+    }
+
+    expected := Registers{
+        sp:      SPRegs{0x20008000, 0},
+        lr:      0xffffffff,
+        pc:      0x8,
+        Epsr:    Epsr{T: true},
+        Mode:    MODE_THREAD,
+        Control: Control{Npriv: false, Spsel: MSP},
+    }
+
+    if cpu.Regs != expected {
+        t.Errorf("After reset:\n%s", cpu.Regs.Pretty())
+        t.Errorf("Expected:\n%s", expected.Pretty())
+    }
+}
+
+func TestResetArmVector(t *testing.T) {
+    bus := NewBus()
+    bus.Load(FLASH_BASE, []byte{
+        0x00, 0x80, 0x00, 0x20, // Initial SP: 0x20008000
+        0x08, 0x00, 0x00, 0x00, // Reset vector: 0x8, without the Thumb bit
+    })
+
+    cpu := NewCPU(bus)
+
+    if err := cpu.Reset(); err != nil {
+        t.Fatalf("Reset: %v", err)
+    }
+
+    if cpu.Regs.Epsr.T || cpu.Regs.Pc() != 0x8 {
+        t.Errorf("After reset:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestResetUnmappedVectorTable(t *testing.T) {
+    cpu := NewCPU(new(Bus))
+
+    if err := cpu.Reset(); err != (BusError{Addr: VECTOR_TABLE, Size: 4, Write: false}) {
+        t.Errorf("Reset: %v, expected bus error", err)
+    }
+}
+
+func TestStepITBlock(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x2000, // movs r0, #0
+        0xbf14, // ite ne
+        0x2101, // movne r1, #1
+        0x2201, // moveq r2, #1
+        0x2301, // movs r3, #1
+    ))
+
+    for i := 0; i < 4; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    /* The skipped movne still moved ITSTATE along, and neither
+     * instruction in the block set flags */
+    if cpu.Regs.R(1) != 0 || cpu.Regs.R(2) != 1 || !cpu.Regs.Apsr.Z || cpu.Regs.InITBlock() {
+        t.Errorf("After IT block:\n%s", cpu.Regs.Pretty())
+    }
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.R(3) != 1 || cpu.Regs.Apsr.Z || cpu.Regs.Pc() != 10 {
+        t.Errorf("After IT block:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestFetchLocatesPCRelative(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x2000,         // movs r0, #0
+        0x4801,         // ldr r0, [pc, #4]
+        0xf20f, 0x0008, // adr.w r0, #8
+    ))
+
+    _, instr, _ := cpu.Fetch(2)
+    if instr != (LdrLitT1{Rt: 0, Imm: 4, Addr: 2, setflags: NEVER}) {
+        t.Errorf("instr: %#v, expected address 2", instr)
+    }
+
+    _, instr, _ = cpu.Fetch(4)
+    if instr != (AdrT2{Rd: 0, Imm: 8, Addr: 4, setflags: NEVER}) {
+        t.Errorf("instr: %#v, expected address 4", instr)
+    }
+}
+
+func TestStepFunctionCall(t *testing.T) {
+    bus := NewBus()
+    bus.Load(FLASH_BASE, image16(
+        0x2003,         // movs r0, #3
+        0xf000, 0xf802, // bl f
+        0x2201, // movs r2, #1
+        0xe7fe, // b .
+        0xb510, // f: push {r4, lr}
+        0x0004, // movs r4, r0
+        0x1920, // adds r0, r4, r4
+        0xbd10, // pop {r4, pc}
+    ))
+
+    cpu := thumb_cpu(bus)
+    cpu.Regs.SetR(SP, RAM_BASE+RAM_SIZE)
+    cpu.Regs.SetR(4, 0x44)
+
+    for i := 0; i < 7; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    /* The callee saved r4 and returned through the pushed LR */
+    if cpu.Regs.R(0) != 6 || cpu.Regs.R(2) != 1 || cpu.Regs.R(4) != 0x44 || cpu.Regs.R(LR) != 0x7 {
+        t.Errorf("After call:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Regs.Sp() != RAM_BASE+RAM_SIZE || cpu.Regs.Pc() != 0x8 || !cpu.Regs.Epsr.T {
+        t.Errorf("After call:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestStepMovwMovt(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0xf241, 0x0534, // movw r5, #0x1034
+        0xf2c4, 0x0502, // movt r5, #0x4002
+    ))
+
+    for i := 0; i < 2; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if cpu.Regs.R(5) != 0x40021034 || cpu.Regs.Pc() != 8 {
+        t.Errorf("After movw/movt:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestStepTableBranch(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0x2002,         // movs r0, #2
+        0xe8df, 0xf000, // tbb [pc, r0]
+        0x0302, 0x0004, // .byte 2, 3, 4, 0
+        0x2101, // movs r1, #1
+        0x2102, // movs r1, #2
+        0x2103, // movs r1, #3
+    ))
+
+    for i := 0; i < 3; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    /* The table is indexed from the tbb's PC, which is the address of the
+     * table itself */
+    if cpu.Regs.R(1) != 3 || cpu.Regs.Pc() != 0x10 {
+        t.Errorf("After tbb:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestStepMemoryHint(t *testing.T) {
+    cpu := thumb_cpu(image16(
+        0xf891, 0xf004, // pld [r1, #4]
+        0xf991, 0xf004, // pli [r1, #4]
+        0xf8b1, 0xf004, // ldrh.w pc, [r1, #4], an unallocated hint
+    ))
+
+    /* Hints to addresses nothing responds to have no effect either */
+    cpu.Regs.SetR(1, 0x40000000)
+
+    for _, pc := range []uint32{4, 8, 12} {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+
+        if cpu.Regs.Pc() != pc || cpu.Regs.Mode != MODE_THREAD {
+            t.Errorf("After hint:\n%s", cpu.Regs.Pretty())
+        }
+    }
+}
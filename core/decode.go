@@ -23,6 +23,7 @@ type FetchedInstr interface {
     Decode() (DecodedInstr, error)
     String() string
     Uint32() uint32
+    Size() uint32
 }
 
 type FetchedInstr16 uint16
@@ -37,21 +38,18 @@ func (instr FetchedInstr16) Decode() (DecodedInstr, error) {
         return nil, ErrIncompleteInstruction
     }
 
-    /* Check for a matching opcode */
-    for opcode, decode := range InstrOpcodes16 {
-        if opcode.Match(instr) {
-            /* Instruction identified, now decode it */
-            return decode(instr), nil
-        }
-    }
-
-    return UndefinedInstr{}, ErrUndefinedInstruction
+    return InstrOpcodes16.Decode(instr)
 }
 
 func (instr FetchedInstr16) Uint32() uint32 {
     return uint32(instr)
 }
 
+/* Length of the instruction in bytes */
+func (instr FetchedInstr16) Size() uint32 {
+    return 2
+}
+
 func (instr FetchedInstr16) String() string {
     return fmt.Sprintf("%.4x", uint16(instr))
 }
@@ -62,20 +60,18 @@ func (upper FetchedInstr16) Extend(lower FetchedInstr16) FetchedInstr32 {
 }
 
 func (instr FetchedInstr32) Decode() (DecodedInstr, error) {
-    /* Check for a matching opcode */
-    for opcode, decode := range InstrOpcodes32 {
-        if opcode.Match(instr) {
-            return decode(instr), nil
-        }
-    }
-
-    return UndefinedInstr{}, ErrUndefinedInstruction
+    return InstrOpcodes32.Decode(instr)
 }
 
 func (instr FetchedInstr32) Uint32() uint32 {
     return uint32(instr)
 }
 
+/* Length of the instruction in bytes */
+func (instr FetchedInstr32) Size() uint32 {
+    return 4
+}
+
 func (instr FetchedInstr32) String() string {
     return fmt.Sprintf("%.8x", uint32(instr))
 }
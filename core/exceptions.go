@@ -0,0 +1,238 @@
+package core
+
+import (
+    "bytes"
+    "fmt"
+)
+
+/* Exception numbers
+ * ARMv7-M ARM B1.5.2 */
+const (
+    EXC_RESET       = 1
+    EXC_NMI         = 2
+    EXC_HARD_FAULT  = 3
+    EXC_MEM_MANAGE  = 4
+    EXC_BUS_FAULT   = 5
+    EXC_USAGE_FAULT = 6
+    EXC_SVCALL      = 11
+    EXC_DEBUG_MON   = 12
+    EXC_PENDSV      = 14
+    EXC_SYSTICK     = 15
+
+    NUM_EXCEPTIONS = 512
+)
+
+/* EXC_RETURN values
+ * ARMv7-M ARM B1.5.8 */
+const (
+    EXC_RETURN_HANDLER    = 0xfffffff1
+    EXC_RETURN_THREAD_MSP = 0xfffffff9
+    EXC_RETURN_THREAD_PSP = 0xfffffffd
+)
+
+/* UsageFault, identified by its UsageFault Status Register bits
+ * ARMv7-M ARM B3.2.15 */
+type UsageFault uint16
+
+const (
+    UFSR_UNDEFINSTR UsageFault = 1 << 0
+    UFSR_INVSTATE   UsageFault = 1 << 1
+    UFSR_INVPC      UsageFault = 1 << 2
+    UFSR_NOCP       UsageFault = 1 << 3
+    UFSR_UNALIGNED  UsageFault = 1 << 8
+    UFSR_DIVBYZERO  UsageFault = 1 << 9
+)
+
+var usage_fault_names = map[UsageFault]string{
+    UFSR_UNDEFINSTR: "UNDEFINSTR",
+    UFSR_INVSTATE:   "INVSTATE",
+    UFSR_INVPC:      "INVPC",
+    UFSR_NOCP:       "NOCP",
+    UFSR_UNALIGNED:  "UNALIGNED",
+    UFSR_DIVBYZERO:  "DIVBYZERO",
+}
+
+func (fault UsageFault) Error() string {
+    var b bytes.Buffer
+
+    fmt.Fprintf(&b, "UsageFault:")
+
+    for bit := uint(0); bit < 16; bit++ {
+        if name, ok := usage_fault_names[fault&(1<<bit)]; ok {
+            fmt.Fprintf(&b, " %s", name)
+        }
+    }
+
+    return b.String()
+}
+
+/* Record the fault status for err, and take the exception that reports it.
+ *
+ * Faults are taken as HardFault unless the fault handler is enabled in SHCSR
+ * and the processor is in Thread mode.  All configurable priorities are 0,
+ * so no fault can preempt a running handler.  A fault during HardFault or NMI,
+ * or one whose handler cannot be entered, locks up the processor, which is
+ * left at the faulting instruction.
+ *
+ * Returns nil once the handler has been entered, or the error from
+ * entering it if that failed.  Otherwise returns err, which is not an
+ * architectural fault if it is neither a UsageFault nor a BusError. */
+func (cpu *CPU) raise(err error, return_addr uint32, fetch bool) error {
+    var number uint16
+    var enable uint32
+
+    switch fault := err.(type) {
+    case UsageFault:
+        cpu.Scs.Cfsr |= uint32(fault) << 16
+        number, enable = EXC_USAGE_FAULT, SHCSR_USGFAULTENA
+    case BusError:
+        if fetch {
+            cpu.Scs.Cfsr |= CFSR_IBUSERR
+        } else {
+            cpu.Scs.Cfsr |= CFSR_PRECISERR | CFSR_BFARVALID
+            cpu.Scs.Bfar = fault.Addr
+        }
+        number, enable = EXC_BUS_FAULT, SHCSR_BUSFAULTENA
+    default:
+        return err
+    }
+
+    if (cpu.Scs.Shcsr&enable) == 0 || cpu.Regs.Mode == MODE_HANDLER {
+        if cpu.Regs.Ipsr.ExcpNum == EXC_HARD_FAULT || cpu.Regs.Ipsr.ExcpNum == EXC_NMI {
+            return err
+        }
+
+        cpu.Scs.Hfsr |= HFSR_FORCED
+        number = EXC_HARD_FAULT
+    }
+
+    return cpu.ExceptionEntry(number, return_addr)
+}
+
+/* Stack the caller-saved registers and branch to the handler for number.
+ * If the vector cannot be read or the frame cannot be stacked, the
+ * registers are left unchanged.
+ * ARMv7-M ARM B1.5.6 */
+func (cpu *CPU) ExceptionEntry(number uint16, return_addr uint32) error {
+    regs := &cpu.Regs
+    bus := processor_bus{cpu: cpu}
+
+    vector, err := bus.Read32(cpu.Scs.Vtor + 4*uint32(number))
+    if err != nil {
+        cpu.Scs.Hfsr |= HFSR_VECTTBL
+        return err
+    }
+
+    /* The frame is always aligned to 8 bytes, recording in the stacked
+     * xPSR whether padding was needed */
+    sp := regs.Sp()
+    frameptr := (sp - 0x20) &^ 0x4
+    xpsr := regs.Xpsr()
+    if (sp & 0x4) != 0 {
+        xpsr |= 1 << 9
+    }
+
+    frame := []uint32{regs.R(0), regs.R(1), regs.R(2), regs.R(3), regs.R(12), regs.R(LR), return_addr, xpsr}
+    for i, value := range frame {
+        if err := bus.Write32(frameptr+4*uint32(i), value); err != nil {
+            return err
+        }
+    }
+
+    regs.SetR(SP, frameptr)
+
+    if regs.Mode == MODE_HANDLER {
+        regs.SetR(LR, EXC_RETURN_HANDLER)
+    } else if regs.Control.Spsel == MSP {
+        regs.SetR(LR, EXC_RETURN_THREAD_MSP)
+    } else {
+        regs.SetR(LR, EXC_RETURN_THREAD_PSP)
+    }
+
+    regs.Mode = MODE_HANDLER
+    regs.Ipsr.ExcpNum = number
+    regs.Epsr.IT = 0
+    regs.Control.Spsel = MSP
+    regs.Epsr.T = (vector & 0x1) != 0
+    regs.BranchTo(vector &^ 0x1)
+    regs.ClearExclusiveLocal()
+
+    cpu.active[number] = true
+
+    return nil
+}
+
+/* Unstack the frame saved by ExceptionEntry, returning to the mode and
+ * stack selected by exc_return
+ * ARMv7-M ARM B1.5.8 */
+func (cpu *CPU) ExceptionReturn(exc_return uint32) error {
+    regs := &cpu.Regs
+    bus := processor_bus{cpu: cpu}
+
+    returning := regs.Ipsr.ExcpNum
+    nested := 0
+    for _, active := range cpu.active {
+        if active {
+            nested++
+        }
+    }
+
+    invalid := !cpu.active[returning]
+
+    var mode Mode
+    var spsel SPType
+
+    /* Bits 27:4 must all be set */
+    if (exc_return & 0x0ffffff0) != 0x0ffffff0 {
+        invalid = true
+    }
+
+    switch exc_return & 0xf {
+    case 0x1:
+        mode, spsel = MODE_HANDLER, MSP
+        invalid = invalid || nested == 1
+    case 0x9:
+        mode, spsel = MODE_THREAD, MSP
+        invalid = invalid || (nested != 1 && (cpu.Scs.Ccr&CCR_NONBASETHRDENA) == 0)
+    case 0xd:
+        mode, spsel = MODE_THREAD, PSP
+        invalid = invalid || (nested != 1 && (cpu.Scs.Ccr&CCR_NONBASETHRDENA) == 0)
+    default:
+        invalid = true
+    }
+
+    if invalid {
+        /* The fault is taken on top of the handler that tried to return */
+        return cpu.raise(UFSR_INVPC, exc_return, false)
+    }
+
+    var frame [8]uint32
+    frameptr := regs.sp[spsel]
+    for i := range frame {
+        value, err := bus.Read32(frameptr + 4*uint32(i))
+        if err != nil {
+            return err
+        }
+        frame[i] = value
+    }
+
+    cpu.active[returning] = false
+
+    regs.Mode = mode
+    regs.Control.Spsel = spsel
+
+    xpsr := frame[7]
+    regs.sp[spsel] = (frameptr + 0x20) | (((xpsr >> 9) & 0x1) << 2)
+
+    regs.SetR(0, frame[0])
+    regs.SetR(1, frame[1])
+    regs.SetR(2, frame[2])
+    regs.SetR(3, frame[3])
+    regs.SetR(12, frame[4])
+    regs.SetR(LR, frame[5])
+    regs.SetXpsr(xpsr)
+    regs.BranchTo(frame[6])
+    regs.ClearExclusiveLocal()
+
+    return nil
+}
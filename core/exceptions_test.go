@@ -0,0 +1,428 @@
+package core
+
+import (
+    "testing"
+)
+
+const (
+    TEST_STACK       = 0x20008000
+    TEST_HARD_FAULT  = 0x100
+    TEST_USAGE_FAULT = 0x180
+    TEST_THREAD_CODE = 0x200
+)
+
+/* CPU out of reset, with a vector table for HardFault and UsageFault and
+ * the handlers and thread code loaded at their addresses */
+func exception_cpu(t *testing.T, hard_fault []uint16, usage_fault []uint16, code []uint16) *CPU {
+    bus := NewBus()
+
+    vectors := make(Block, 4*(EXC_USAGE_FAULT+1))
+    vectors.Write32(0, TEST_STACK)
+    vectors.Write32(4*EXC_RESET, TEST_THREAD_CODE|1)
+    vectors.Write32(4*EXC_HARD_FAULT, TEST_HARD_FAULT|1)
+    vectors.Write32(4*EXC_USAGE_FAULT, TEST_USAGE_FAULT|1)
+
+    bus.Load(VECTOR_TABLE, vectors)
+    bus.Load(TEST_HARD_FAULT, image16(hard_fault...))
+    bus.Load(TEST_USAGE_FAULT, image16(usage_fault...))
+    bus.Load(TEST_THREAD_CODE, image16(code...))
+
+    cpu := NewCPU(bus)
+    if err := cpu.Reset(); err != nil {
+        t.Fatalf("Reset: %v", err)
+    }
+
+    return cpu
+}
+
+func read_frame(t *testing.T, cpu *CPU) [8]uint32 {
+    var frame [8]uint32
+
+    for i := range frame {
+        value, err := cpu.Mem.Read32(cpu.Regs.Sp() + 4*uint32(i))
+        if err != nil {
+            t.Fatalf("Reading exception frame: %v", err)
+        }
+        frame[i] = value
+    }
+
+    return frame
+}
+
+func TestExceptionInvalidState(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0x2080, // movs r0, #0x80
+        0x4700, // bx r0
+    })
+
+    for i := 0; i < 2; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    /* bx cleared the Thumb bit, so the fault is raised before fetching
+     * from the branch target */
+    if cpu.Regs.Epsr.T || cpu.Regs.Pc() != 0x80 {
+        t.Fatalf("After bx to an even address:\n%s", cpu.Regs.Pretty())
+    }
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    /* UsageFault is disabled, so it escalates to HardFault */
+    if cpu.Regs.Mode != MODE_HANDLER || cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT ||
+        cpu.Regs.Pc() != TEST_HARD_FAULT || !cpu.Regs.Epsr.T || cpu.Regs.R(LR) != EXC_RETURN_THREAD_MSP {
+        t.Errorf("After fault:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Scs.Cfsr != uint32(UFSR_INVSTATE)<<16 || cpu.Scs.Hfsr != HFSR_FORCED {
+        t.Errorf("CFSR = %#x, HFSR = %#x", cpu.Scs.Cfsr, cpu.Scs.Hfsr)
+    }
+
+    if cpu.Regs.Sp() != TEST_STACK-0x20 {
+        t.Fatalf("SP = %#x, expected %#x", cpu.Regs.Sp(), TEST_STACK-0x20)
+    }
+
+    /* The stacked PC is the instruction that could not be executed, and the
+     * stacked xPSR has the Thumb bit clear */
+    frame := read_frame(t, cpu)
+    expected := [8]uint32{0x80, 0, 0, 0, 0, 0xffffffff, 0x80, 0}
+    if frame != expected {
+        t.Errorf("frame: %#x, expected %#x", frame, expected)
+    }
+}
+
+func TestExceptionUsageFaultEnabled(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0x2001, // movs r0, #1
+        0xde00, // udf #0
+    })
+
+    cpu.Scs.Shcsr = SHCSR_USGFAULTENA
+    cpu.Regs.Apsr.C = true
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.Ipsr.ExcpNum != EXC_USAGE_FAULT || cpu.Regs.Pc() != TEST_USAGE_FAULT {
+        t.Errorf("After fault:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Scs.Cfsr != uint32(UFSR_UNDEFINSTR)<<16 || cpu.Scs.Hfsr != 0 {
+        t.Errorf("CFSR = %#x, HFSR = %#x", cpu.Scs.Cfsr, cpu.Scs.Hfsr)
+    }
+
+    frame := read_frame(t, cpu)
+    expected := [8]uint32{1, 0, 0, 0, 0, 0xffffffff, TEST_THREAD_CODE + 2, 0x21000000}
+    if frame != expected {
+        t.Errorf("frame: %#x, expected %#x", frame, expected)
+    }
+}
+
+func TestExceptionReturn(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0x2005, // movs r0, #5
+        0x4770, // bx lr
+    }, []uint16{
+        0x2001, // movs r0, #1
+        0x2102, // movs r1, #2
+    })
+
+    /* Misalign the stack, so that the frame needs padding */
+    cpu.Regs.SetR(SP, TEST_STACK-4)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    /* The handler's movs clears N, which the return restores */
+    cpu.Regs.Apsr.N = true
+
+    if err := cpu.ExceptionEntry(EXC_USAGE_FAULT, cpu.Regs.Pc()); err != nil {
+        t.Fatalf("ExceptionEntry: %v", err)
+    }
+
+    if cpu.Regs.Sp() != TEST_STACK-0x28 {
+        t.Errorf("SP = %#x, expected %#x", cpu.Regs.Sp(), TEST_STACK-0x28)
+    }
+
+    for i := 0; i < 2; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    expected := Registers{
+        r:        GeneralRegs{1},
+        sp:       SPRegs{TEST_STACK - 4, 0},
+        lr:       0xffffffff,
+        pc:       TEST_THREAD_CODE + 2,
+        branched: true,
+        exc_ret:  true,
+        Apsr:     Apsr{N: true},
+        Epsr:     Epsr{T: true},
+        Mode:     MODE_THREAD,
+    }
+
+    if cpu.Regs != expected {
+        t.Errorf("After exception return:\n%s", cpu.Regs.Pretty())
+        t.Errorf("Expected:\n%s", expected.Pretty())
+    }
+
+    if err := cpu.Step(); err != nil || cpu.Regs.R(1) != 2 {
+        t.Errorf("Step after return: %v\n%s", err, cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionReturnInvalid(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0x4770, // bx lr
+    })
+
+    /* Returning to Handler mode with only one exception active */
+    cpu.ExceptionEntry(EXC_USAGE_FAULT, TEST_THREAD_CODE)
+    cpu.Regs.SetR(LR, EXC_RETURN_HANDLER)
+    cpu.Regs.BranchTo(TEST_THREAD_CODE)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT || cpu.Regs.Pc() != TEST_HARD_FAULT {
+        t.Errorf("After invalid return:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Scs.Cfsr != uint32(UFSR_INVPC)<<16 {
+        t.Errorf("CFSR = %#x", cpu.Scs.Cfsr)
+    }
+}
+
+func TestExceptionReturnMalformed(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0x4770, // bx lr
+    }, []uint16{0xe7fe})
+
+    cpu.ExceptionEntry(EXC_USAGE_FAULT, TEST_THREAD_CODE)
+
+    /* The low nibble is valid, but bits 27:4 are not all set */
+    cpu.Regs.SetR(LR, 0xfff0fff9)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT || cpu.Scs.Cfsr != uint32(UFSR_INVPC)<<16 {
+        t.Errorf("After malformed return:\n%sCFSR = %#x", cpu.Regs.Pretty(), cpu.Scs.Cfsr)
+    }
+}
+
+func TestExceptionReturnOnlyByInterworking(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0x46f7, // mov pc, lr
+    }, []uint16{0xe7fe})
+
+    cpu.ExceptionEntry(EXC_USAGE_FAULT, TEST_THREAD_CODE)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    /* mov pc is a plain branch, which clears bit 0 and stays in the handler */
+    if cpu.Regs.Mode != MODE_HANDLER || cpu.Regs.Ipsr.ExcpNum != EXC_USAGE_FAULT ||
+        cpu.Regs.Pc() != EXC_RETURN_THREAD_MSP&^0x1 {
+        t.Errorf("After mov pc, lr:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionLockup(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{
+        0xde00, // udf #0
+    }, []uint16{0xe7fe}, []uint16{
+        0xde00, // udf #0
+    })
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    sp := cpu.Regs.Sp()
+
+    /* A fault in the HardFault handler cannot be taken */
+    if err := cpu.Step(); err != UFSR_UNDEFINSTR {
+        t.Fatalf("Step: %v, expected UNDEFINSTR", err)
+    }
+
+    if cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT || cpu.Regs.Pc() != TEST_HARD_FAULT || cpu.Regs.Sp() != sp {
+        t.Errorf("After lockup:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionRunsHandler(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{
+        0x2207, // movs r2, #7
+        0xde01, // udf #1
+    }, []uint16{0xe7fe}, []uint16{
+        0x2001, // movs r0, #1
+        0xde00, // udf #0
+    })
+
+    /* Run carries on into the HardFault handler, and stops when the
+     * handler's own fault locks up the processor */
+    if err := cpu.Run(); err != UFSR_UNDEFINSTR {
+        t.Fatalf("Run: %v, expected UNDEFINSTR", err)
+    }
+
+    if cpu.Regs.R(0) != 1 || cpu.Regs.R(2) != 7 || cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT ||
+        cpu.Regs.Pc() != TEST_HARD_FAULT+2 {
+        t.Errorf("After lockup:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionStackingFailure(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0xde00, // udf #0
+    })
+
+    /* Nothing is mapped below the stack, so the frame cannot be written */
+    cpu.Regs.SetR(SP, 0x40000000)
+
+    if err := cpu.Step(); err != (BusError{Addr: 0x40000000 - 0x20, Size: 4, Write: true}) {
+        t.Fatalf("Step: %v, expected bus error", err)
+    }
+
+    if cpu.Regs.Mode != MODE_THREAD || cpu.Regs.Pc() != TEST_THREAD_CODE ||
+        cpu.Regs.Sp() != 0x40000000 || cpu.Regs.R(LR) != 0xffffffff {
+        t.Errorf("After failed exception entry:\n%s", cpu.Regs.Pretty())
+    }
+
+    if cpu.Scs.Cfsr != uint32(UFSR_UNDEFINSTR)<<16 {
+        t.Errorf("CFSR = %#x", cpu.Scs.Cfsr)
+    }
+}
+
+func TestExceptionPreciseBusError(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0x2001, // movs r0, #1
+        0x07c0, // lsls r0, r0, #31
+        0x6841, // ldr r1, [r0, #4]
+    })
+
+    for i := 0; i < 2; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT || cpu.Scs.Bfar != 0x80000004 ||
+        cpu.Scs.Cfsr != CFSR_PRECISERR|CFSR_BFARVALID {
+        t.Errorf("After bus error:\n%sCFSR = %#x, BFAR = %#x", cpu.Regs.Pretty(), cpu.Scs.Cfsr, cpu.Scs.Bfar)
+    }
+
+    /* The faulting load is the return address */
+    if frame := read_frame(t, cpu); frame[6] != TEST_THREAD_CODE+4 {
+        t.Errorf("Stacked PC = %#x, expected %#x", frame[6], TEST_THREAD_CODE+4)
+    }
+}
+
+func TestExceptionReturnPop(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0xb510, // push {r4, lr}
+        0x2405, // movs r4, #5
+        0xbd10, // pop {r4, pc}
+    }, []uint16{
+        0x2001, // movs r0, #1
+        0x2102, // movs r1, #2
+    })
+
+    cpu.Regs.SetR(4, 0x44)
+
+    if err := cpu.ExceptionEntry(EXC_USAGE_FAULT, TEST_THREAD_CODE); err != nil {
+        t.Fatalf("ExceptionEntry: %v", err)
+    }
+
+    for i := 0; i < 3; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if cpu.Regs.Mode != MODE_THREAD || cpu.Regs.Pc() != TEST_THREAD_CODE ||
+        cpu.Regs.Sp() != TEST_STACK || cpu.Regs.R(4) != 0x44 {
+        t.Errorf("After exception return:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionReturnPopWide(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0xe92d, 0x41f0, // push.w {r4, r5, r6, r7, r8, lr}
+        0xf04f, 0x0805, // mov.w r8, #5
+        0xe8bd, 0x81f0, // pop.w {r4, r5, r6, r7, r8, pc}
+    }, []uint16{
+        0x2001, // movs r0, #1
+        0x2102, // movs r1, #2
+    })
+
+    cpu.Regs.SetR(8, 0x88)
+
+    if err := cpu.ExceptionEntry(EXC_USAGE_FAULT, TEST_THREAD_CODE); err != nil {
+        t.Fatalf("ExceptionEntry: %v", err)
+    }
+
+    for i := 0; i < 3; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if cpu.Regs.Mode != MODE_THREAD || cpu.Regs.Pc() != TEST_THREAD_CODE ||
+        cpu.Regs.Sp() != TEST_STACK || cpu.Regs.R(8) != 0x88 {
+        t.Errorf("After exception return:\n%s", cpu.Regs.Pretty())
+    }
+}
+
+func TestExceptionClearsExclusiveMonitor(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{
+        0xe851, 0x3f00, // ldrex r3, [r1]
+        0x4770, // bx lr
+    }, []uint16{
+        0xe851, 0x0f00, // ldrex r0, [r1]
+        0xe841, 0x0200, // strex r2, r0, [r1]
+    })
+
+    cpu.Regs.SetR(1, RAM_BASE)
+
+    if err := cpu.Step(); err != nil {
+        t.Fatalf("Step: %v", err)
+    }
+
+    if err := cpu.ExceptionEntry(EXC_USAGE_FAULT, cpu.Regs.Pc()); err != nil {
+        t.Fatalf("ExceptionEntry: %v", err)
+    }
+
+    if cpu.Regs.monitor.Exclusive {
+        t.Errorf("Monitor still exclusive after exception entry")
+    }
+
+    /* The handler's LDREX is forgotten when it returns, so the thread's
+     * STREX fails */
+    for i := 0; i < 3; i++ {
+        if err := cpu.Step(); err != nil {
+            t.Fatalf("Step: %v", err)
+        }
+    }
+
+    if cpu.Regs.Mode != MODE_THREAD || cpu.Regs.R(2) != 1 || cpu.Regs.monitor.Exclusive {
+        t.Errorf("After exception return:\n%s", cpu.Regs.Pretty())
+    }
+}
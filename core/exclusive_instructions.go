@@ -0,0 +1,198 @@
+package core
+
+import "fmt"
+
+/* Address operand of a load or store exclusive, which only has an offset
+ * for the word forms */
+func exclusive_operand(instr InstrFields) string {
+    if instr.Imm == 0 {
+        return fmt.Sprintf("[%s]", instr.Rn)
+    }
+    return fmt.Sprintf("[%s, #%d]", instr.Rn, instr.Imm)
+}
+
+/* LDREX - Load Register Exclusive
+ * ARM ARM A7.7.51
+ * Encoding T1 */
+type LdrexT1 InstrFields
+
+func Ldrex32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+    Imm := (raw_instr & 0xff) << 2
+
+    return LdrexT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrexT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadExclusive(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr LdrexT1) String() string {
+    return fmt.Sprintf("ldrex %s, %s", instr.Rt, exclusive_operand(InstrFields(instr)))
+}
+
+/* LDREXB - Load Register Exclusive Byte
+ * ARM ARM A7.7.52
+ * Encoding T1 */
+type LdrexbT1 InstrFields
+
+func Ldrexb32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+
+    return LdrexbT1{Rt: Rt, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrexbT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadExclusive(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr LdrexbT1) String() string {
+    return fmt.Sprintf("ldrexb %s, [%s]", instr.Rt, instr.Rn)
+}
+
+/* LDREXH - Load Register Exclusive Halfword
+ * ARM ARM A7.7.53
+ * Encoding T1 */
+type LdrexhT1 InstrFields
+
+func Ldrexh32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+
+    return LdrexhT1{Rt: Rt, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrexhT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadExclusive(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr LdrexhT1) String() string {
+    return fmt.Sprintf("ldrexh %s, [%s]", instr.Rt, instr.Rn)
+}
+
+/* STREX - Store Register Exclusive
+ * ARM ARM A7.7.164
+ * Encoding T1 */
+type StrexT1 InstrFields
+
+func Strex32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex((raw_instr >> 8) & 0xf)
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+    Imm := (raw_instr & 0xff) << 2
+
+    return StrexT1{Rd: Rd, Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr StrexT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rd == instr.Rn || instr.Rd == instr.Rt {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreExclusive(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr StrexT1) String() string {
+    return fmt.Sprintf("strex %s, %s, %s", instr.Rd, instr.Rt, exclusive_operand(InstrFields(instr)))
+}
+
+/* STREXB - Store Register Exclusive Byte
+ * ARM ARM A7.7.165
+ * Encoding T1 */
+type StrexbT1 InstrFields
+
+func Strexb32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex(raw_instr & 0xf)
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+
+    return StrexbT1{Rd: Rd, Rt: Rt, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr StrexbT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rd == instr.Rn || instr.Rd == instr.Rt {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreExclusive(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr StrexbT1) String() string {
+    return fmt.Sprintf("strexb %s, %s, [%s]", instr.Rd, instr.Rt, instr.Rn)
+}
+
+/* STREXH - Store Register Exclusive Halfword
+ * ARM ARM A7.7.166
+ * Encoding T1 */
+type StrexhT1 InstrFields
+
+func Strexh32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex(raw_instr & 0xf)
+    Rt := RegIndex((raw_instr >> 12) & 0xf)
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+
+    return StrexhT1{Rd: Rd, Rt: Rt, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr StrexhT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rt) || instr.Rn == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rd == instr.Rn || instr.Rd == instr.Rt {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreExclusive(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr StrexhT1) String() string {
+    return fmt.Sprintf("strexh %s, %s, [%s]", instr.Rd, instr.Rt, instr.Rn)
+}
+
+/* CLREX - Clear Exclusive
+ * ARM ARM A7.7.23
+ * Encoding T1 */
+type ClrexT1 InstrFields
+
+func Clrex32T1(instr FetchedInstr) DecodedInstr {
+    return ClrexT1{setflags: NEVER}
+}
+
+func (instr ClrexT1) Execute(regs *Registers, mem Memory) error {
+    regs.ClearExclusiveLocal()
+
+    return nil
+}
+
+func (instr ClrexT1) String() string {
+    return "clrex"
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyExclusive(t *testing.T) {
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8510f00), instr_valid: true},  // ldrex r0, [r1]
+        {instr: FetchedInstr32(0xe8510f02), instr_valid: true},  // ldrex r0, [r1, #8]
+        {instr: FetchedInstr32(0xe8410201), instr_valid: false}, // strex r2, r0, [r1, #4]
+    }, reflect.TypeOf(LdrexT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8410201), instr_valid: true},  // strex r2, r0, [r1, #4]
+        {instr: FetchedInstr32(0xe8510f00), instr_valid: false}, // ldrex r0, [r1]
+    }, reflect.TypeOf(StrexT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8d43f4f), instr_valid: true},  // ldrexb r3, [r4]
+        {instr: FetchedInstr32(0xe8d43f5f), instr_valid: false}, // ldrexh r3, [r4]
+    }, reflect.TypeOf(LdrexbT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8d43f5f), instr_valid: true},  // ldrexh r3, [r4]
+        {instr: FetchedInstr32(0xe8d1f012), instr_valid: false}, // tbh [r1, r2, lsl #1]
+    }, reflect.TypeOf(LdrexhT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8c43f45), instr_valid: true},  // strexb r5, r3, [r4]
+        {instr: FetchedInstr32(0xe8c43f55), instr_valid: false}, // strexh r5, r3, [r4]
+    }, reflect.TypeOf(StrexbT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xe8c43f55), instr_valid: true},  // strexh r5, r3, [r4]
+        {instr: FetchedInstr32(0xe8c43f45), instr_valid: false}, // strexb r5, r3, [r4]
+    }, reflect.TypeOf(StrexhT1{}))
+
+    test_identify(t, []IdentifyCase{
+        {instr: FetchedInstr32(0xf3bf8f2f), instr_valid: true},  // clrex
+        {instr: FetchedInstr32(0xf000f800), instr_valid: false}, // bl
+    }, reflect.TypeOf(ClrexT1{}))
+}
+
+func TestDecodeExclusive(t *testing.T) {
+    test_decode(t, []DecodeCase{
+        // ldrex r0, [r1, #8]
+        {instr: FetchedInstr32(0xe8510f02), decoded: LdrexT1{Rt: 0, Rn: 1, Imm: 8, setflags: NEVER}},
+    }, Ldrex32T1)
+
+    test_decode(t, []DecodeCase{
+        // strex r2, r0, [r1, #4]
+        {instr: FetchedInstr32(0xe8410201), decoded: StrexT1{Rd: 2, Rt: 0, Rn: 1, Imm: 4, setflags: NEVER}},
+    }, Strex32T1)
+
+    test_decode(t, []DecodeCase{
+        // ldrexh r3, [r4]
+        {instr: FetchedInstr32(0xe8d43f5f), decoded: LdrexhT1{Rt: 3, Rn: 4, setflags: NEVER}},
+    }, Ldrexh32T1)
+
+    test_decode(t, []DecodeCase{
+        // strexb r5, r3, [r4]
+        {instr: FetchedInstr32(0xe8c43f45), decoded: StrexbT1{Rd: 5, Rt: 3, Rn: 4, setflags: NEVER}},
+    }, Strexb32T1)
+}
+
+func TestExecuteExclusive(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldrex r0, [r1, #4]
+        {instr: LdrexT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0x12345678, 0}, monitor: Monitor{Exclusive: true, Addr: 4}},
+            mem:      Block{0, 0, 0, 0, 0x78, 0x56, 0x34, 0x12}},
+        // ldrexh r0, [r1], unaligned
+        {instr: LdrexhT1{Rt: 0, Rn: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1}},
+            expected: Registers{r: GeneralRegs{0, 1}},
+            mem:      Block{0, 0, 0, 0},
+            err:      UFSR_UNALIGNED},
+        // ldrexb r0, [r1], outside of memory
+        {instr: LdrexbT1{Rt: 0, Rn: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 4}},
+            expected: Registers{r: GeneralRegs{0, 4}},
+            mem:      Block{0, 0, 0, 0},
+            err:      BusError{Addr: 4, Size: 1, Write: false}},
+        // strex r2, r0, [r1, #4], after ldrex from the same address
+        {instr: StrexT1{Rd: 2, Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0xcafe, 0, 7}, monitor: Monitor{Exclusive: true, Addr: 4}},
+            expected:     Registers{r: GeneralRegs{0xcafe, 0, 0}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0xfe, 0xca, 0, 0}},
+        // strex r2, r0, [r1], after ldrex from a different address
+        {instr: StrexT1{Rd: 2, Rt: 0, Rn: 1, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0xcafe, 0, 7}, monitor: Monitor{Exclusive: true, Addr: 4}},
+            expected:     Registers{r: GeneralRegs{0xcafe, 0, 1}},
+            mem:          Block{0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0}},
+        // strexb r2, r0, [r1], without ldrex
+        {instr: StrexbT1{Rd: 2, Rt: 0, Rn: 1, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0xcafe, 1}},
+            expected:     Registers{r: GeneralRegs{0xcafe, 1, 1}},
+            mem:          Block{0, 0},
+            expected_mem: Block{0, 0}},
+        // strexh r2, r0, [r1]
+        {instr: StrexhT1{Rd: 2, Rt: 0, Rn: 1, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0xcafe, 2, 7}, monitor: Monitor{Exclusive: true, Addr: 2}},
+            expected:     Registers{r: GeneralRegs{0xcafe, 2, 0}},
+            mem:          Block{0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0xfe, 0xca}},
+        // strexh r2, r0, [r1], unaligned leaves the monitor alone
+        {instr: StrexhT1{Rd: 2, Rt: 0, Rn: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0xcafe, 1, 7}, monitor: Monitor{Exclusive: true, Addr: 1}},
+            expected: Registers{r: GeneralRegs{0xcafe, 1, 7}, monitor: Monitor{Exclusive: true, Addr: 1}},
+            mem:      Block{0, 0, 0, 0},
+            err:      UFSR_UNALIGNED},
+        // strex r0, r0, [r1] (UNPREDICTABLE)
+        {instr: StrexT1{Rd: 0, Rt: 0, Rn: 1, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0xcafe, 0}, monitor: Monitor{Exclusive: true, Addr: 0}},
+            expected:     Registers{r: GeneralRegs{0xcafe, 0}, monitor: Monitor{Exclusive: true, Addr: 0}},
+            mem:          Block{0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0}},
+        // ldrex sp, [r1] (UNPREDICTABLE)
+        {instr: LdrexT1{Rt: SP, Rn: 1, setflags: NEVER},
+            regs:     Registers{},
+            expected: Registers{},
+            mem:      Block{1, 0, 0, 0}},
+        // clrex
+        {instr: ClrexT1{setflags: NEVER},
+            regs:     Registers{monitor: Monitor{Exclusive: true, Addr: 4}},
+            expected: Registers{}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringExclusive(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xe8510f00, expected: "ldrex r0, [r1]"},
+        {instr: 0xe8510f02, expected: "ldrex r0, [r1, #8]"},
+        {instr: 0xe8410201, expected: "strex r2, r0, [r1, #4]"},
+        {instr: 0xe8d43f4f, expected: "ldrexb r3, [r4]"},
+        {instr: 0xe8d43f5f, expected: "ldrexh r3, [r4]"},
+        {instr: 0xe8c43f45, expected: "strexb r5, r3, [r4]"},
+        {instr: 0xe8c43f55, expected: "strexh r5, r3, [r4]"},
+        {instr: 0xf3bf8f2f, expected: "clrex"},
+    }
+
+    for _, test := range cases {
+        instr, err := test.instr.Decode()
+        if err != nil {
+            t.Errorf("%#x: %v", uint32(test.instr), err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", uint32(test.instr), actual, test.expected)
+        }
+    }
+}
@@ -0,0 +1,69 @@
+package core
+
+/* Local exclusive monitor, in the Exclusive Access state after a load
+ * exclusive from Addr and in the Open Access state otherwise
+ * ARMv7-M ARM A3.4 */
+type Monitor struct {
+    Exclusive bool
+    Addr      uint32
+}
+
+/* Mark addr for exclusive access */
+func (regs *Registers) SetExclusiveMonitors(addr uint32) {
+    regs.monitor = Monitor{Exclusive: true, Addr: addr}
+}
+
+/* Whether a store exclusive to addr may go ahead.  The monitor returns to
+ * the Open Access state whether or not it does. */
+func (regs *Registers) ExclusiveMonitorsPass(addr uint32) bool {
+    pass := regs.monitor.Exclusive && regs.monitor.Addr == addr
+    regs.ClearExclusiveLocal()
+
+    return pass
+}
+
+func (regs *Registers) ClearExclusiveLocal() {
+    regs.monitor = Monitor{}
+}
+
+/* Perform load exclusive instruction, from the size-aligned Rn plus offset */
+func LoadExclusive(regs *Registers, mem Memory, instr InstrFields, size uint32) error {
+    addr := regs.R(instr.Rn) + instr.Imm
+
+    if addr&(size-1) != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    value, err := MemRead(mem, addr, size)
+    if err != nil {
+        return err
+    }
+
+    regs.SetExclusiveMonitors(addr)
+    regs.SetR(instr.Rt, value)
+
+    return nil
+}
+
+/* Perform store exclusive instruction, to the size-aligned Rn plus offset,
+ * setting Rd to 0 if the store was made and 1 if it was not */
+func StoreExclusive(regs *Registers, mem Memory, instr InstrFields, size uint32) error {
+    addr := regs.R(instr.Rn) + instr.Imm
+
+    if addr&(size-1) != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    if !regs.ExclusiveMonitorsPass(addr) {
+        regs.SetR(instr.Rd, 1)
+        return nil
+    }
+
+    if err := MemWrite(mem, addr, size, regs.R(instr.Rt)); err != nil {
+        return err
+    }
+
+    regs.SetR(instr.Rd, 0)
+
+    return nil
+}
@@ -0,0 +1,322 @@
+package core
+
+import "fmt"
+
+/* Fields of the 16-bit extend and reverse encodings */
+func extend_fields16(raw_instr uint32) (Rd RegIndex, Rm RegIndex) {
+    Rd = RegIndex(raw_instr & 0x7)
+    Rm = RegIndex((raw_instr >> 3) & 0x7)
+
+    return Rd, Rm
+}
+
+/* Fields shared by the 32-bit extend encodings, with the rotation of Rm
+ * decoded from rotate */
+func extend_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, Rm RegIndex, rotation Shift) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Rm = RegIndex(raw_instr & 0xf)
+    rotation = Shift{srtype: SRTYPE_ROR, amount: uint8(((raw_instr >> 4) & 0x3) * 8)}
+
+    return Rd, Rn, Rm, rotation
+}
+
+/* SXTH - Signed Extend Halfword
+ * ARM ARM A7.7.181
+ * Encoding T1 */
+type SxthT1 InstrFields
+
+func Sxth16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return SxthT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr SxthT1) Execute(regs *Registers, mem Memory) error {
+    Extend(regs, InstrFields(instr), 2, true, false)
+
+    return nil
+}
+
+func (instr SxthT1) String() string {
+    return fmt.Sprintf("sxth %s, %s", instr.Rd, instr.Rm)
+}
+
+/* SXTB - Signed Extend Byte
+ * ARM ARM A7.7.179
+ * Encoding T1 */
+type SxtbT1 InstrFields
+
+func Sxtb16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return SxtbT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr SxtbT1) Execute(regs *Registers, mem Memory) error {
+    Extend(regs, InstrFields(instr), 1, true, false)
+
+    return nil
+}
+
+func (instr SxtbT1) String() string {
+    return fmt.Sprintf("sxtb %s, %s", instr.Rd, instr.Rm)
+}
+
+/* UXTH - Unsigned Extend Halfword
+ * ARM ARM A7.7.223
+ * Encoding T1 */
+type UxthT1 InstrFields
+
+func Uxth16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return UxthT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr UxthT1) Execute(regs *Registers, mem Memory) error {
+    Extend(regs, InstrFields(instr), 2, false, false)
+
+    return nil
+}
+
+func (instr UxthT1) String() string {
+    return fmt.Sprintf("uxth %s, %s", instr.Rd, instr.Rm)
+}
+
+/* UXTB - Unsigned Extend Byte
+ * ARM ARM A7.7.221
+ * Encoding T1 */
+type UxtbT1 InstrFields
+
+func Uxtb16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return UxtbT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr UxtbT1) Execute(regs *Registers, mem Memory) error {
+    Extend(regs, InstrFields(instr), 1, false, false)
+
+    return nil
+}
+
+func (instr UxtbT1) String() string {
+    return fmt.Sprintf("uxtb %s, %s", instr.Rd, instr.Rm)
+}
+
+/* SXTH - Signed Extend Halfword
+ * ARM ARM A7.7.181
+ * Encoding T2 */
+type SxthT2 InstrFields
+
+func Sxth32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, rotation := extend_fields(instr.Uint32())
+
+    return SxthT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr SxthT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 2, true, false)
+
+    return nil
+}
+
+func (instr SxthT2) String() string {
+    return fmt.Sprintf("sxth.w %s, %s%s", instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* SXTB - Signed Extend Byte
+ * ARM ARM A7.7.179
+ * Encoding T2 */
+type SxtbT2 InstrFields
+
+func Sxtb32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, rotation := extend_fields(instr.Uint32())
+
+    return SxtbT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr SxtbT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 1, true, false)
+
+    return nil
+}
+
+func (instr SxtbT2) String() string {
+    return fmt.Sprintf("sxtb.w %s, %s%s", instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* UXTH - Unsigned Extend Halfword
+ * ARM ARM A7.7.223
+ * Encoding T2 */
+type UxthT2 InstrFields
+
+func Uxth32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, rotation := extend_fields(instr.Uint32())
+
+    return UxthT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr UxthT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 2, false, false)
+
+    return nil
+}
+
+func (instr UxthT2) String() string {
+    return fmt.Sprintf("uxth.w %s, %s%s", instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* UXTB - Unsigned Extend Byte
+ * ARM ARM A7.7.221
+ * Encoding T2 */
+type UxtbT2 InstrFields
+
+func Uxtb32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, rotation := extend_fields(instr.Uint32())
+
+    return UxtbT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr UxtbT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 1, false, false)
+
+    return nil
+}
+
+func (instr UxtbT2) String() string {
+    return fmt.Sprintf("uxtb.w %s, %s%s", instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* SXTAH - Signed Extend and Add Halfword
+ * ARM ARM A7.7.178
+ * Encoding T1 */
+type SxtahT1 InstrFields
+
+func Sxtah32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, rotation := extend_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Sxth32T2(instr)
+    }
+
+    return SxtahT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr SxtahT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 2, true, true)
+
+    return nil
+}
+
+func (instr SxtahT1) String() string {
+    return fmt.Sprintf("sxtah %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* SXTAB - Signed Extend and Add Byte
+ * ARM ARM A7.7.176
+ * Encoding T1 */
+type SxtabT1 InstrFields
+
+func Sxtab32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, rotation := extend_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Sxtb32T2(instr)
+    }
+
+    return SxtabT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr SxtabT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 1, true, true)
+
+    return nil
+}
+
+func (instr SxtabT1) String() string {
+    return fmt.Sprintf("sxtab %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* UXTAH - Unsigned Extend and Add Halfword
+ * ARM ARM A7.7.220
+ * Encoding T1 */
+type UxtahT1 InstrFields
+
+func Uxtah32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, rotation := extend_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Uxth32T2(instr)
+    }
+
+    return UxtahT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr UxtahT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 2, false, true)
+
+    return nil
+}
+
+func (instr UxtahT1) String() string {
+    return fmt.Sprintf("uxtah %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* UXTAB - Unsigned Extend and Add Byte
+ * ARM ARM A7.7.218
+ * Encoding T1 */
+type UxtabT1 InstrFields
+
+func Uxtab32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, rotation := extend_fields(instr.Uint32())
+
+    if Rn == PC {
+        return Uxtb32T2(instr)
+    }
+
+    return UxtabT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: rotation, setflags: NEVER}
+}
+
+func (instr UxtabT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Extend(regs, InstrFields(instr), 1, false, true)
+
+    return nil
+}
+
+func (instr UxtabT1) String() string {
+    return fmt.Sprintf("uxtab %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
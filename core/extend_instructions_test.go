@@ -0,0 +1,148 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyExtend16(t *testing.T) {
+    cases := []struct {
+        instr      FetchedInstr16
+        instr_type reflect.Type
+    }{
+        {instr: 0xb208, instr_type: reflect.TypeOf(SxthT1{})}, // sxth r0, r1
+        {instr: 0xb25a, instr_type: reflect.TypeOf(SxtbT1{})}, // sxtb r2, r3
+        {instr: 0xb2ac, instr_type: reflect.TypeOf(UxthT1{})}, // uxth r4, r5
+        {instr: 0xb2fe, instr_type: reflect.TypeOf(UxtbT1{})}, // uxtb r6, r7
+    }
+
+    for _, test := range cases {
+        test_identify(t, []IdentifyCase{{instr: test.instr, instr_valid: true}}, test.instr_type)
+    }
+}
+
+func TestDecodeUxtb16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // uxtb r6, r7
+        {instr: FetchedInstr16(0xb2fe), decoded: UxtbT1{Rd: 6, Rm: 7, Rn: 0, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Uxtb16T1)
+}
+
+func TestExecuteExtend16(t *testing.T) {
+    cases := []ExecuteCase{
+        // sxth r0, r1
+        {instr: SxthT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x12348000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffff8000, 0x12348000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sxtb r2, r3
+        {instr: SxtbT1{Rd: 2, Rm: 3, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x1234567f, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x7f, 0x1234567f, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // uxth r4, r5
+        {instr: UxthT1{Rd: 4, Rm: 5, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffff8000, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x8000, 0xffff8000, 6, 7, 8, 9, 10, 11, 12}}},
+        // uxtb r6, r7
+        {instr: UxtbT1{Rd: 6, Rm: 7, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffff80, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x80, 0xffffff80, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifySxtahT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xfa01f082), instr_valid: true},  // sxtah r0, r1, r2
+        {instr: FetchedInstr32(0xfa0ff889), instr_valid: false}, // sxth.w r8, r9
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SxtahT1{}))
+}
+
+func TestDecodeExtend32(t *testing.T) {
+    cases := []DecodeCase{
+        // sxtab r3, r4, r5, ror #8
+        {instr: FetchedInstr32(0xfa44f395), decoded: SxtabT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 8}, setflags: NEVER}},
+        // sxtb.w r0, r1, ror #8
+        {instr: FetchedInstr32(0xfa4ff091), decoded: SxtbT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 8}, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Sxtab32T1)
+}
+
+func TestExecuteExtend32(t *testing.T) {
+    cases := []ExecuteCase{
+        // sxth.w r8, r9
+        {instr: SxthT2{Rd: 8, Rm: 9, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x0000fffe, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0xfffffffe, 0x0000fffe, 10, 11, 12}}},
+        // sxtb.w r0, r1, ror #8
+        {instr: SxtbT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 8}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x00008000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffffff80, 0x00008000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // uxth.w r2, r3, ror #16
+        {instr: UxthT2{Rd: 2, Rm: 3, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 16}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xabcd1234, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0xabcd, 0xabcd1234, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // uxtb.w r10, r11, ror #24
+        {instr: UxtbT2{Rd: 10, Rm: 11, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 24}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0x12345678, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x12, 0x12345678, 12}}},
+        // uxtb.w sp, r11 (UNPREDICTABLE)
+        {instr: UxtbT2{Rd: SP, Rm: 11, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sxtah r0, r1, r2
+        {instr: SxtahT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x100, 0xffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xff, 0x100, 0xffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sxtab r3, r4, r5, ror #8
+        {instr: SxtabT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 8}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x10, 0x8000, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffff90, 0x10, 0x8000, 6, 7, 8, 9, 10, 11, 12}}},
+        // uxtah r6, r7, r8, ror #16
+        {instr: UxtahT1{Rd: 6, Rm: 8, Rn: 7, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 16}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x10000, 0xffff0000, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x1ffff, 0x10000, 0xffff0000, 9, 10, 11, 12}}},
+        // uxtab r9, r10, r11, ror #24
+        {instr: UxtabT1{Rd: 9, Rm: 11, Rn: 10, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 24}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0xffffffff, 0x01000000, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0, 0xffffffff, 0x01000000, 12}}},
+        // uxtab r9, sp, r11 (UNPREDICTABLE)
+        {instr: UxtabT1{Rd: 9, Rm: 11, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringExtend(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr
+        expected string
+    }{
+        {instr: FetchedInstr16(0xb208), expected: "sxth r0, r1"},
+        {instr: FetchedInstr16(0xb2fe), expected: "uxtb r6, r7"},
+        {instr: FetchedInstr32(0xfa0ff889), expected: "sxth.w r8, r9"},
+        {instr: FetchedInstr32(0xfa1ff2a3), expected: "uxth.w r2, r3, ror #16"},
+        {instr: FetchedInstr32(0xfa01f082), expected: "sxtah r0, r1, r2"},
+        {instr: FetchedInstr32(0xfa5af9bb), expected: "uxtab r9, r10, r11, ror #24"},
+    }
+
+    for _, test := range cases {
+        instr, err := test.instr.Decode()
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
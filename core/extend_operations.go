@@ -0,0 +1,18 @@
+package core
+
+/* Perform extend instruction, taking the low size bytes of the rotated Rm
+ * and adding Rn to them if add */
+func Extend(regs *Registers, instr InstrFields, size uint32, signed bool, add bool) {
+    rotated, _ := instr.Shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    value := rotated & (1<<(8*size) - 1)
+
+    if signed {
+        value = SignExtend(value, size)
+    }
+
+    if add {
+        value += regs.R(instr.Rn)
+    }
+
+    regs.SetR(instr.Rd, value)
+}
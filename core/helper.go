@@ -13,3 +13,15 @@ func utobool(i uint8) bool {
     }
     return false
 }
+
+/* Align(x, y), for y a power of 2
+ * ARMv7-M ARM D6.5.2 */
+func align(x uint32, y uint32) uint32 {
+    return x &^ (y - 1)
+}
+
+/* BadReg(n), registers that most 32-bit instructions cannot use
+ * ARMv7-M ARM A7.3.1 */
+func bad_reg(n RegIndex) bool {
+    return n == SP || n == PC
+}
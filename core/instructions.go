@@ -2,8 +2,25 @@ package core
 
 type DecodeFunc func(FetchedInstr) DecodedInstr
 
+/* Execute the instruction, with access to the system bus for loads and
+ * stores.  Returns an error if a memory access fails. */
 type DecodedInstr interface {
-    Execute(*Registers)
+    Execute(*Registers, Memory) error
+}
+
+/* Instructions that address memory relative to PC, which need their own
+ * address to print the target */
+type PCRelativeInstr interface {
+    DecodedInstr
+    At(addr uint32) DecodedInstr
+}
+
+/* Record the address that instr was fetched from, if it uses it */
+func Locate(instr DecodedInstr, addr uint32) DecodedInstr {
+    if relative, ok := instr.(PCRelativeInstr); ok {
+        return relative.At(addr)
+    }
+    return instr
 }
 
 type SetFlags uint8
@@ -30,9 +47,54 @@ func (setflags SetFlags) ShouldSetFlags(regs Registers) bool {
 }
 
 type InstrFields struct {
-    setflags SetFlags
-    Imm      uint32
-    Rd       RegIndex
-    Rm       RegIndex
-    Rn       RegIndex
+    setflags  SetFlags
+    Imm       uint32
+    Rd        RegIndex
+    Rm        RegIndex
+    Rn        RegIndex
+    Rt        RegIndex     // Only for loads and stores
+    RegList   RegisterList // Only for loads and stores of multiple registers
+    Cond      Condition    // Only for instructions that encode a condition
+    Addr      uint32       // Only for PC-relative instructions, once located
+    Shift     Shift        // Only for instructions that shift a register by a constant
+    Width     uint32       // Only for bitfield instructions, with the lsb in Imm
+    Ra        RegIndex     // Only for multiply accumulate instructions
+    RdHi      RegIndex     // Only for long multiplies, with the low word in Rd
+    Rt2       RegIndex     // Only for loads and stores of two registers
+    PostIndex bool         // Only for loads and stores, whether Rn is accessed before adding the offset
+    Wback     bool         // Only for loads and stores, whether Rn is updated with the offset
+    Carry     ImmCarry     // Only for bitwise and move instructions with a modified immediate constant in Imm
+}
+
+/* Fields shared by the data processing (modified and plain binary immediate)
+ * encodings, where imm12 is i:imm3:imm8 and S selects whether condition codes
+ * are set */
+func mod_imm_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, imm12 uint32, setflags SetFlags) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    imm12 = ((raw_instr>>26)&0x1)<<11 | ((raw_instr>>12)&0x7)<<8 | (raw_instr & 0xff)
+
+    setflags = NEVER
+    if (raw_instr>>20)&0x1 != 0 {
+        setflags = ALWAYS
+    }
+
+    return Rd, Rn, imm12, setflags
+}
+
+/* Fields shared by the data processing (shifted register) encodings, with
+ * the shift decoded from type and imm3:imm2 */
+func shifted_reg_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, Rm RegIndex, shift Shift, setflags SetFlags) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Rm = RegIndex(raw_instr & 0xf)
+    imm5 := ((raw_instr>>12)&0x7)<<2 | (raw_instr>>6)&0x3
+    shift = DecodeImmShift((raw_instr>>4)&0x3, imm5)
+
+    setflags = NEVER
+    if (raw_instr>>20)&0x1 != 0 {
+        setflags = ALWAYS
+    }
+
+    return Rd, Rn, Rm, shift, setflags
 }
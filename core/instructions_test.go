@@ -1,6 +1,7 @@
 package core
 
 import (
+    "bytes"
     "reflect"
     "testing"
 )
@@ -26,6 +27,11 @@ type ExecuteCase struct {
     instr    DecodedInstr
     regs     Registers
     expected Registers
+
+    // Only for instructions that access memory
+    mem          Block
+    expected_mem Block
+    err          error
 }
 
 func test_identify(t *testing.T, cases []IdentifyCase, instr_type reflect.Type) {
@@ -49,7 +55,18 @@ func test_decode(t *testing.T, cases []DecodeCase, decode DecodeFunc) {
 func test_execute(t *testing.T, cases []ExecuteCase) {
     for _, test := range cases {
         original := test.regs
-        test.instr.Execute(&test.regs)
+        err := test.instr.Execute(&test.regs, test.mem)
+
+        if err != test.err {
+            t.Errorf("instr: %#v", test.instr)
+            t.Errorf("err: %v, expected: %v", err, test.err)
+        }
+
+        if test.expected_mem != nil && !bytes.Equal(test.mem, test.expected_mem) {
+            t.Errorf("instr: %#v", test.instr)
+            t.Errorf("mem: % x", []byte(test.mem))
+            t.Errorf("expected mem: % x", []byte(test.expected_mem))
+        }
 
         if test.regs != test.expected {
             t.Errorf("instr: %#v", test.instr)
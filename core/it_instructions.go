@@ -0,0 +1,59 @@
+package core
+
+import (
+    "bytes"
+    "fmt"
+)
+
+/* IT - If-Then
+ * ARM ARM A7.7.38
+ * Cond is firstcond and Imm is the ITSTATE value firstcond:mask */
+type IfThen InstrFields
+
+func IfThen16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Cond := Condition((raw_instr >> 4) & 0xf)
+    Imm := raw_instr & 0xff
+
+    return IfThen{Cond: Cond, Imm: Imm, setflags: NEVER}
+}
+
+func (instr IfThen) Execute(regs *Registers, mem Memory) error {
+    mask := instr.Imm & 0xf
+
+    if instr.Cond == 0xf || (instr.Cond == COND_AL && (mask&(mask-1)) != 0) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if regs.InITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.Epsr.IT = uint16(instr.Imm)
+
+    return nil
+}
+
+func (instr IfThen) String() string {
+    var b bytes.Buffer
+
+    fmt.Fprintf(&b, "it")
+
+    /* The lowest set bit of the mask ends the block.  The bits above
+     * it give the condition of each further instruction, relative to
+     * the lowest bit of firstcond. */
+    for bit := uint(3); bit > 0 && (instr.Imm&((1<<bit)-1)) != 0; bit-- {
+        if ((instr.Imm >> bit) & 0x1) == (uint32(instr.Cond) & 0x1) {
+            fmt.Fprintf(&b, "t")
+        } else {
+            fmt.Fprintf(&b, "e")
+        }
+    }
+
+    if instr.Cond == COND_AL {
+        fmt.Fprintf(&b, " al")
+    } else {
+        fmt.Fprintf(&b, " %s", instr.Cond)
+    }
+
+    return b.String()
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyIfThen(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xbf08), instr_valid: true},  // it eq
+        {instr: FetchedInstr16(0xbf14), instr_valid: true},  // ite ne
+        {instr: FetchedInstr16(0xbfc5), instr_valid: true},  // ittet gt
+        {instr: FetchedInstr16(0xbfe8), instr_valid: true},  // it al
+        {instr: FetchedInstr16(0xbf00), instr_valid: false}, // nop
+        {instr: FetchedInstr16(0xbf10), instr_valid: false}, // yield
+        {instr: FetchedInstr16(0xd0fe), instr_valid: false}, // beq #-4
+        {instr: FetchedInstr16(0xffff), instr_valid: false},
+    }
+
+    test_identify(t, cases, reflect.TypeOf(IfThen{}))
+}
+
+func TestDecodeIfThen16(t *testing.T) {
+    cases := []DecodeCase{
+        // it eq
+        {instr: FetchedInstr16(0xbf08), decoded: IfThen{Cond: COND_EQ, Imm: 0x08, setflags: NEVER}},
+        // ite ne
+        {instr: FetchedInstr16(0xbf14), decoded: IfThen{Cond: COND_NE, Imm: 0x14, setflags: NEVER}},
+        // ittet gt
+        {instr: FetchedInstr16(0xbfc5), decoded: IfThen{Cond: COND_GT, Imm: 0xc5, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, IfThen16)
+}
+
+func TestExecuteIfThen(t *testing.T) {
+    cases := []ExecuteCase{
+        // it eq
+        {instr: IfThen{Cond: COND_EQ, Imm: 0x08, setflags: NEVER},
+            regs:     Registers{},
+            expected: Registers{Epsr: Epsr{IT: 0x08}}},
+        // ittet gt
+        {instr: IfThen{Cond: COND_GT, Imm: 0xc5, setflags: NEVER},
+            regs:     Registers{Apsr: Apsr{Z: true}},
+            expected: Registers{Apsr: Apsr{Z: true}, Epsr: Epsr{IT: 0xc5}}},
+        // itt al
+        {instr: IfThen{Cond: COND_AL, Imm: 0xe4, setflags: NEVER},
+            regs:     Registers{},
+            expected: Registers{Epsr: Epsr{IT: 0xe4}}},
+        // ite al (UNPREDICTABLE)
+        {instr: IfThen{Cond: COND_AL, Imm: 0xec, setflags: NEVER},
+            regs:     Registers{},
+            expected: Registers{}},
+        // it eq, inside an IT block (UNPREDICTABLE)
+        {instr: IfThen{Cond: COND_EQ, Imm: 0x08, setflags: NEVER},
+            regs:     Registers{Epsr: Epsr{IT: 0x14}},
+            expected: Registers{Epsr: Epsr{IT: 0x14}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringIfThen(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr16
+        expected string
+    }{
+        {instr: 0xbf08, expected: "it eq"},
+        {instr: 0xbf14, expected: "ite ne"},
+        {instr: 0xbfc5, expected: "ittet gt"},
+        {instr: 0xbf11, expected: "iteee ne"},
+        {instr: 0xbf1f, expected: "itttt ne"},
+        {instr: 0xbfe8, expected: "it al"},
+    }
+
+    for _, test := range cases {
+        instr := IfThen16(test.instr).(IfThen)
+        if instr.String() != test.expected {
+            t.Errorf("%v: %q, expected %q", test.instr, instr.String(), test.expected)
+        }
+    }
+}
+
+func TestITAdvance(t *testing.T) {
+    /* ittet gt: gt, gt, le, gt */
+    regs := Registers{Epsr: Epsr{IT: 0xc5}}
+    expected := []Condition{COND_GT, COND_GT, COND_LE, COND_GT, COND_AL}
+
+    for i, cond := range expected {
+        if regs.CurrentCond() != cond {
+            t.Errorf("instruction %d: cond %d, expected %d (IT = %#x)", i, regs.CurrentCond(), cond, regs.Epsr.IT)
+        }
+
+        if regs.InITBlock() != (i < 4) || regs.LastInITBlock() != (i == 3) {
+            t.Errorf("instruction %d: IT = %#x", i, regs.Epsr.IT)
+        }
+
+        regs.ITAdvance()
+    }
+}
@@ -0,0 +1,2032 @@
+package core
+
+import "fmt"
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T1 */
+type LdrImmT1 InstrFields
+
+func LdrImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := ((raw_instr >> 6) & 0x1f) << 2
+
+    return LdrImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrImmT1) Execute(regs *Registers, mem Memory) error {
+    return LoadImmediate(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrImmT1) String() string {
+    return fmt.Sprintf("ldr %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T2 */
+type LdrImmT2 InstrFields
+
+func LdrImm16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 8) & 0x7)
+    Imm := (raw_instr & 0xff) << 2
+
+    return LdrImmT2{Rt: Rt, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrImmT2) Execute(regs *Registers, mem Memory) error {
+    return LoadImmediate(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrImmT2) String() string {
+    return fmt.Sprintf("ldr %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDR (literal)
+ * ARM ARM A7.7.43
+ * Encoding T1 */
+type LdrLitT1 InstrFields
+
+func LdrLit16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 8) & 0x7)
+    Imm := (raw_instr & 0xff) << 2
+
+    return LdrLitT1{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrLitT1) Execute(regs *Registers, mem Memory) error {
+    return LoadLiteral(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrLitT1) String() string {
+    return fmt.Sprintf("ldr %s, [pc, #%d] @ %#x", instr.Rt, instr.Imm, align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDR (literal)
+ * ARM ARM A7.7.43
+ * Encoding T2
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrLitT2 InstrFields
+
+func LdrLit32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Imm := load_literal_fields(instr.Uint32())
+
+    return LdrLitT2{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrLitT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == PC && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadLiteral(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrLitT2) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrLitT2) String() string {
+    return fmt.Sprintf("ldr.w %s, [pc, #%d] @ %#x", instr.Rt, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T1 */
+type LdrbImmT1 InstrFields
+
+func LdrbImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := (raw_instr >> 6) & 0x1f
+
+    return LdrbImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrbImmT1) Execute(regs *Registers, mem Memory) error {
+    return LoadImmediate(regs, mem, InstrFields(instr), 1, false)
+}
+
+func (instr LdrbImmT1) String() string {
+    return fmt.Sprintf("ldrb %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T1 */
+type LdrhImmT1 InstrFields
+
+func LdrhImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := ((raw_instr >> 6) & 0x1f) << 1
+
+    return LdrhImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrhImmT1) Execute(regs *Registers, mem Memory) error {
+    return LoadImmediate(regs, mem, InstrFields(instr), 2, false)
+}
+
+func (instr LdrhImmT1) String() string {
+    return fmt.Sprintf("ldrh %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T1 */
+type StrImmT1 InstrFields
+
+func StrImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := ((raw_instr >> 6) & 0x1f) << 2
+
+    return StrImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr StrImmT1) Execute(regs *Registers, mem Memory) error {
+    return StoreImmediate(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr StrImmT1) String() string {
+    return fmt.Sprintf("str %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T2 */
+type StrImmT2 InstrFields
+
+func StrImm16T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex((raw_instr >> 8) & 0x7)
+    Imm := (raw_instr & 0xff) << 2
+
+    return StrImmT2{Rt: Rt, Rn: SP, Imm: Imm, setflags: NEVER}
+}
+
+func (instr StrImmT2) Execute(regs *Registers, mem Memory) error {
+    return StoreImmediate(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr StrImmT2) String() string {
+    return fmt.Sprintf("str %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T1 */
+type StrbImmT1 InstrFields
+
+func StrbImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := (raw_instr >> 6) & 0x1f
+
+    return StrbImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr StrbImmT1) Execute(regs *Registers, mem Memory) error {
+    return StoreImmediate(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr StrbImmT1) String() string {
+    return fmt.Sprintf("strb %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T1 */
+type StrhImmT1 InstrFields
+
+func StrhImm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Imm := ((raw_instr >> 6) & 0x1f) << 1
+
+    return StrhImmT1{Rt: Rt, Rn: Rn, Imm: Imm, setflags: NEVER}
+}
+
+func (instr StrhImmT1) Execute(regs *Registers, mem Memory) error {
+    return StoreImmediate(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr StrhImmT1) String() string {
+    return fmt.Sprintf("strh %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDR (register)
+ * ARM ARM A7.7.44
+ * Encoding T1 */
+type LdrRegT1 InstrFields
+
+func LdrReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return LdrRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrRegT1) Execute(regs *Registers, mem Memory) error {
+    return LoadRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 4, false)
+}
+
+func (instr LdrRegT1) String() string {
+    return fmt.Sprintf("ldr %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* LDRB (register)
+ * ARM ARM A7.7.47
+ * Encoding T1 */
+type LdrbRegT1 InstrFields
+
+func LdrbReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return LdrbRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrbRegT1) Execute(regs *Registers, mem Memory) error {
+    return LoadRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 1, false)
+}
+
+func (instr LdrbRegT1) String() string {
+    return fmt.Sprintf("ldrb %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* LDRH (register)
+ * ARM ARM A7.7.56
+ * Encoding T1 */
+type LdrhRegT1 InstrFields
+
+func LdrhReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return LdrhRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrhRegT1) Execute(regs *Registers, mem Memory) error {
+    return LoadRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 2, false)
+}
+
+func (instr LdrhRegT1) String() string {
+    return fmt.Sprintf("ldrh %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* LDRSB (register)
+ * ARM ARM A7.7.60
+ * Encoding T1 */
+type LdrsbRegT1 InstrFields
+
+func LdrsbReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return LdrsbRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrsbRegT1) Execute(regs *Registers, mem Memory) error {
+    return LoadRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 1, true)
+}
+
+func (instr LdrsbRegT1) String() string {
+    return fmt.Sprintf("ldrsb %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* LDRSH (register)
+ * ARM ARM A7.7.64
+ * Encoding T1 */
+type LdrshRegT1 InstrFields
+
+func LdrshReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return LdrshRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr LdrshRegT1) Execute(regs *Registers, mem Memory) error {
+    return LoadRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 2, true)
+}
+
+func (instr LdrshRegT1) String() string {
+    return fmt.Sprintf("ldrsh %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* STR (register)
+ * ARM ARM A7.7.159
+ * Encoding T1 */
+type StrRegT1 InstrFields
+
+func StrReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return StrRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr StrRegT1) Execute(regs *Registers, mem Memory) error {
+    return StoreRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 4)
+}
+
+func (instr StrRegT1) String() string {
+    return fmt.Sprintf("str %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* STRB (register)
+ * ARM ARM A7.7.161
+ * Encoding T1 */
+type StrbRegT1 InstrFields
+
+func StrbReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return StrbRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr StrbRegT1) Execute(regs *Registers, mem Memory) error {
+    return StoreRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 1)
+}
+
+func (instr StrbRegT1) String() string {
+    return fmt.Sprintf("strb %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* STRH (register)
+ * ARM ARM A7.7.168
+ * Encoding T1 */
+type StrhRegT1 InstrFields
+
+func StrhReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rt := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+    Rm := RegIndex((raw_instr >> 6) & 0x7)
+
+    return StrhRegT1{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr StrhRegT1) Execute(regs *Registers, mem Memory) error {
+    return StoreRegister(regs, mem, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, 2)
+}
+
+func (instr StrhRegT1) String() string {
+    return fmt.Sprintf("strh %s, [%s, %s]", instr.Rt, instr.Rn, instr.Rm)
+}
+
+/* Fields shared by the 32-bit loads and stores with a 12-bit immediate
+ * offset, which is always added */
+func load_store_imm12_fields(raw_instr uint32) (Rt RegIndex, Rn RegIndex, imm12 uint32) {
+    Rt = RegIndex((raw_instr >> 12) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    imm12 = raw_instr & 0xfff
+
+    return Rt, Rn, imm12
+}
+
+/* Fields shared by the 32-bit loads and stores with an 8-bit immediate
+ * offset, indexed as selected by P, U and W.  A subtracted offset is
+ * stored as its two's complement. */
+func load_store_imm8_fields(raw_instr uint32) (Rt RegIndex, Rn RegIndex, Imm uint32, post_index bool, wback bool) {
+    Rt = RegIndex((raw_instr >> 12) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Imm = raw_instr & 0xff
+
+    if (raw_instr>>9)&0x1 == 0 {
+        Imm = -Imm
+    }
+
+    post_index = (raw_instr>>10)&0x1 == 0
+    wback = (raw_instr>>8)&0x1 == 1
+
+    return Rt, Rn, Imm, post_index, wback
+}
+
+/* Whether P, U and W of an 8-bit immediate offset select an implemented
+ * indexing mode.  P == 0 and W == 0 is UNDEFINED, and P == 1, U == 1 and
+ * W == 0 selects the unprivileged loads and stores, which are not
+ * implemented. */
+func load_store_imm8_valid(raw_instr uint32) bool {
+    puw := (raw_instr >> 8) & 0x7
+
+    return puw != 0x0 && puw != 0x2 && puw != 0x6
+}
+
+/* Fields shared by the 32-bit loads and stores with a register offset,
+ * shifted left by imm2.  Bits 10 to 6 must be zero. */
+func load_store_reg_fields(raw_instr uint32) (Rt RegIndex, Rn RegIndex, Rm RegIndex, shift Shift) {
+    Rt = RegIndex((raw_instr >> 12) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Rm = RegIndex(raw_instr & 0xf)
+    shift = Shift{srtype: SRTYPE_LSL, amount: uint8((raw_instr >> 4) & 0x3)}
+
+    return Rt, Rn, Rm, shift
+}
+
+/* Fields shared by the 32-bit loads from the word-aligned PC plus or
+ * minus imm12.  A subtracted offset is stored as its two's complement. */
+func load_literal_fields(raw_instr uint32) (Rt RegIndex, Imm uint32) {
+    Rt = RegIndex((raw_instr >> 12) & 0xf)
+    Imm = raw_instr & 0xfff
+
+    if (raw_instr>>23)&0x1 == 0 {
+        Imm = -Imm
+    }
+
+    return Rt, Imm
+}
+
+/* Address operand of a load or store with an immediate offset */
+func indexed_operand(instr InstrFields) string {
+    if instr.PostIndex {
+        return fmt.Sprintf("[%s], #%d", instr.Rn, int32(instr.Imm))
+    } else if instr.Wback {
+        return fmt.Sprintf("[%s, #%d]!", instr.Rn, int32(instr.Imm))
+    }
+
+    return fmt.Sprintf("[%s, #%d]", instr.Rn, int32(instr.Imm))
+}
+
+/* Memory hints, which are encoded as byte and halfword loads to PC.  Byte
+ * loads are PLD, or PLI if signed, and halfword loads are unallocated
+ * hints that execute as NOPs.  The unprivileged load encoding is
+ * UNPREDICTABLE, and those that write back to Rn decode as loads, which
+ * treat it as UNPREDICTABLE when they execute.
+ * ARM ARM A5.3.7, A5.3.8 */
+func MemoryHint32(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rn := RegIndex((raw_instr >> 16) & 0xf)
+    signed := (raw_instr>>24)&0x1 == 1
+    halfword := (raw_instr>>21)&0x1 == 1
+    imm12 := (raw_instr>>23)&0x1 == 1
+
+    if Rn != PC && !imm12 && (raw_instr>>8)&0xf == 0xe {
+        return UnpredictableInstr{}
+    } else if halfword {
+        return UnallocatedHint{}
+    }
+
+    if Rn == PC {
+        _, Imm := load_literal_fields(raw_instr)
+        if signed {
+            return PliLitT3{Imm: Imm, setflags: NEVER}
+        }
+        return PldLitT1{Imm: Imm, setflags: NEVER}
+    } else if imm12 {
+        if signed {
+            return PliImmT1{Rn: Rn, Imm: raw_instr & 0xfff, setflags: NEVER}
+        }
+        return PldImmT1{Rn: Rn, Imm: raw_instr & 0xfff, setflags: NEVER}
+    } else if (raw_instr>>11)&0x1 == 1 {
+        if signed {
+            return PliImmT2{Rn: Rn, Imm: -(raw_instr & 0xff), setflags: NEVER}
+        }
+        return PldImmT2{Rn: Rn, Imm: -(raw_instr & 0xff), setflags: NEVER}
+    }
+
+    _, _, Rm, shift := load_store_reg_fields(raw_instr)
+    if signed {
+        return PliRegT1{Rn: Rn, Rm: Rm, Shift: shift, setflags: NEVER}
+    }
+    return PldRegT1{Rn: Rn, Rm: Rm, Shift: shift, setflags: NEVER}
+}
+
+/* Unallocated memory hint, which executes as a NOP
+ * ARM ARM A5.3.8 */
+type UnallocatedHint InstrFields
+
+func (instr UnallocatedHint) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+/* PLD (immediate)
+ * ARM ARM A7.7.93
+ * Encoding T1 */
+type PldImmT1 InstrFields
+
+func (instr PldImmT1) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PldImmT1) String() string {
+    return fmt.Sprintf("pld [%s, #%d]", instr.Rn, instr.Imm)
+}
+
+/* PLD (immediate)
+ * ARM ARM A7.7.93
+ * Encoding T2
+ * The offset is always subtracted, so is stored as its two's complement */
+type PldImmT2 InstrFields
+
+func (instr PldImmT2) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PldImmT2) String() string {
+    return fmt.Sprintf("pld [%s, #%d]", instr.Rn, int32(instr.Imm))
+}
+
+/* PLD (literal)
+ * ARM ARM A7.7.94
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type PldLitT1 InstrFields
+
+func (instr PldLitT1) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PldLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr PldLitT1) String() string {
+    return fmt.Sprintf("pld [pc, #%d] @ %#x", int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* PLD (register)
+ * ARM ARM A7.7.95
+ * Encoding T1 */
+type PldRegT1 InstrFields
+
+func (instr PldRegT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return nil
+}
+
+func (instr PldRegT1) String() string {
+    return fmt.Sprintf("pld [%s, %s%s]", instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T1 */
+type PliImmT1 InstrFields
+
+func (instr PliImmT1) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PliImmT1) String() string {
+    return fmt.Sprintf("pli [%s, #%d]", instr.Rn, instr.Imm)
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T2
+ * The offset is always subtracted, so is stored as its two's complement */
+type PliImmT2 InstrFields
+
+func (instr PliImmT2) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PliImmT2) String() string {
+    return fmt.Sprintf("pli [%s, #%d]", instr.Rn, int32(instr.Imm))
+}
+
+/* PLI (immediate, literal)
+ * ARM ARM A7.7.96
+ * Encoding T3
+ * A negative offset (U == 0) is stored as its two's complement */
+type PliLitT3 InstrFields
+
+func (instr PliLitT3) Execute(regs *Registers, mem Memory) error {
+    return nil
+}
+
+func (instr PliLitT3) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr PliLitT3) String() string {
+    return fmt.Sprintf("pli [pc, #%d] @ %#x", int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* PLI (register)
+ * ARM ARM A7.7.97
+ * Encoding T1 */
+type PliRegT1 InstrFields
+
+func (instr PliRegT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return nil
+}
+
+func (instr PliRegT1) String() string {
+    return fmt.Sprintf("pli [%s, %s%s]", instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T3 */
+type LdrImmT3 InstrFields
+
+func LdrImm32T3(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrLit32T2(instr)
+    }
+
+    return LdrImmT3{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr LdrImmT3) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == PC && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrImmT3) String() string {
+    return fmt.Sprintf("ldr.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDR (immediate)
+ * ARM ARM A7.7.42
+ * Encoding T4 */
+type LdrImmT4 InstrFields
+
+func LdrImm32T4(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrLit32T2(instr)
+    } else if !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return LdrImmT4{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrImmT4) Execute(regs *Registers, mem Memory) error {
+    if instr.Wback && instr.Rn == instr.Rt {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rt == PC && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 4, false)
+}
+
+func (instr LdrImmT4) String() string {
+    return fmt.Sprintf("ldr %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* LDR (register)
+ * ARM ARM A7.7.44
+ * Encoding T2 */
+type LdrRegT2 InstrFields
+
+func LdrReg32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Rm, shift := load_store_reg_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrLit32T2(instr)
+    } else if (raw_instr>>6)&0x1f != 0 {
+        return UndefinedInstr{}
+    }
+
+    return LdrRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr LdrRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rt == PC && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadRegister(regs, mem, InstrFields(instr), instr.Shift, 4, false)
+}
+
+func (instr LdrRegT2) String() string {
+    return fmt.Sprintf("ldr.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T2 */
+type LdrbImmT2 InstrFields
+
+func LdrbImm32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrbLit32T1(instr)
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrbImmT2{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr LdrbImmT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 1, false)
+}
+
+func (instr LdrbImmT2) String() string {
+    return fmt.Sprintf("ldrb.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDRB (immediate)
+ * ARM ARM A7.7.45
+ * Encoding T3 */
+type LdrbImmT3 InstrFields
+
+func LdrbImm32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrbLit32T1(instr)
+    } else if Rt == PC && (raw_instr>>8)&0x5 == 0x4 {
+        return MemoryHint32(instr)
+    } else if !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return LdrbImmT3{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrbImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 1, false)
+}
+
+func (instr LdrbImmT3) String() string {
+    return fmt.Sprintf("ldrb %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* LDRB (literal)
+ * ARM ARM A7.7.46
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrbLitT1 InstrFields
+
+func LdrbLit32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Imm := load_literal_fields(instr.Uint32())
+
+    if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrbLitT1{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrbLitT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadLiteral(regs, mem, InstrFields(instr), 1, false)
+}
+
+func (instr LdrbLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrbLitT1) String() string {
+    return fmt.Sprintf("ldrb.w %s, [pc, #%d] @ %#x", instr.Rt, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDRB (register)
+ * ARM ARM A7.7.47
+ * Encoding T2 */
+type LdrbRegT2 InstrFields
+
+func LdrbReg32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Rm, shift := load_store_reg_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrbLit32T1(instr)
+    } else if (raw_instr>>6)&0x1f != 0 {
+        return UndefinedInstr{}
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrbRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr LdrbRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadRegister(regs, mem, InstrFields(instr), instr.Shift, 1, false)
+}
+
+func (instr LdrbRegT2) String() string {
+    return fmt.Sprintf("ldrb.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T2 */
+type LdrhImmT2 InstrFields
+
+func LdrhImm32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrhLit32T1(instr)
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrhImmT2{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr LdrhImmT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 2, false)
+}
+
+func (instr LdrhImmT2) String() string {
+    return fmt.Sprintf("ldrh.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDRH (immediate)
+ * ARM ARM A7.7.54
+ * Encoding T3 */
+type LdrhImmT3 InstrFields
+
+func LdrhImm32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrhLit32T1(instr)
+    } else if Rt == PC && (raw_instr>>8)&0x5 == 0x4 {
+        return MemoryHint32(instr)
+    } else if !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return LdrhImmT3{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrhImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 2, false)
+}
+
+func (instr LdrhImmT3) String() string {
+    return fmt.Sprintf("ldrh %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* LDRH (literal)
+ * ARM ARM A7.7.55
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrhLitT1 InstrFields
+
+func LdrhLit32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Imm := load_literal_fields(instr.Uint32())
+
+    if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrhLitT1{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrhLitT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadLiteral(regs, mem, InstrFields(instr), 2, false)
+}
+
+func (instr LdrhLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrhLitT1) String() string {
+    return fmt.Sprintf("ldrh.w %s, [pc, #%d] @ %#x", instr.Rt, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDRH (register)
+ * ARM ARM A7.7.56
+ * Encoding T2 */
+type LdrhRegT2 InstrFields
+
+func LdrhReg32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Rm, shift := load_store_reg_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrhLit32T1(instr)
+    } else if (raw_instr>>6)&0x1f != 0 {
+        return UndefinedInstr{}
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrhRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr LdrhRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadRegister(regs, mem, InstrFields(instr), instr.Shift, 2, false)
+}
+
+func (instr LdrhRegT2) String() string {
+    return fmt.Sprintf("ldrh.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDRSB (immediate)
+ * ARM ARM A7.7.58
+ * Encoding T1 */
+type LdrsbImmT1 InstrFields
+
+func LdrsbImm32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrsbLit32T1(instr)
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrsbImmT1{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr LdrsbImmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 1, true)
+}
+
+func (instr LdrsbImmT1) String() string {
+    return fmt.Sprintf("ldrsb.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDRSB (immediate)
+ * ARM ARM A7.7.58
+ * Encoding T2 */
+type LdrsbImmT2 InstrFields
+
+func LdrsbImm32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrsbLit32T1(instr)
+    } else if Rt == PC && (raw_instr>>8)&0x5 == 0x4 {
+        return MemoryHint32(instr)
+    } else if !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return LdrsbImmT2{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrsbImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 1, true)
+}
+
+func (instr LdrsbImmT2) String() string {
+    return fmt.Sprintf("ldrsb %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* LDRSB (literal)
+ * ARM ARM A7.7.59
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrsbLitT1 InstrFields
+
+func LdrsbLit32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Imm := load_literal_fields(instr.Uint32())
+
+    if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrsbLitT1{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrsbLitT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadLiteral(regs, mem, InstrFields(instr), 1, true)
+}
+
+func (instr LdrsbLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrsbLitT1) String() string {
+    return fmt.Sprintf("ldrsb.w %s, [pc, #%d] @ %#x", instr.Rt, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDRSB (register)
+ * ARM ARM A7.7.60
+ * Encoding T2 */
+type LdrsbRegT2 InstrFields
+
+func LdrsbReg32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Rm, shift := load_store_reg_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrsbLit32T1(instr)
+    } else if (raw_instr>>6)&0x1f != 0 {
+        return UndefinedInstr{}
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrsbRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr LdrsbRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadRegister(regs, mem, InstrFields(instr), instr.Shift, 1, true)
+}
+
+func (instr LdrsbRegT2) String() string {
+    return fmt.Sprintf("ldrsb.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDRSH (immediate)
+ * ARM ARM A7.7.62
+ * Encoding T1 */
+type LdrshImmT1 InstrFields
+
+func LdrshImm32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrshLit32T1(instr)
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrshImmT1{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr LdrshImmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 2, true)
+}
+
+func (instr LdrshImmT1) String() string {
+    return fmt.Sprintf("ldrsh.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* LDRSH (immediate)
+ * ARM ARM A7.7.62
+ * Encoding T2 */
+type LdrshImmT2 InstrFields
+
+func LdrshImm32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrshLit32T1(instr)
+    } else if Rt == PC && (raw_instr>>8)&0x5 == 0x4 {
+        return MemoryHint32(instr)
+    } else if !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return LdrshImmT2{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrshImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadImmediate(regs, mem, InstrFields(instr), 2, true)
+}
+
+func (instr LdrshImmT2) String() string {
+    return fmt.Sprintf("ldrsh %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* LDRSH (literal)
+ * ARM ARM A7.7.63
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrshLitT1 InstrFields
+
+func LdrshLit32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Imm := load_literal_fields(instr.Uint32())
+
+    if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrshLitT1{Rt: Rt, Imm: Imm, setflags: NEVER}
+}
+
+func (instr LdrshLitT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadLiteral(regs, mem, InstrFields(instr), 2, true)
+}
+
+func (instr LdrshLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrshLitT1) String() string {
+    return fmt.Sprintf("ldrsh.w %s, [pc, #%d] @ %#x", instr.Rt, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* LDRSH (register)
+ * ARM ARM A7.7.64
+ * Encoding T2 */
+type LdrshRegT2 InstrFields
+
+func LdrshReg32T2(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Rm, shift := load_store_reg_fields(raw_instr)
+
+    if Rn == PC {
+        return LdrshLit32T1(instr)
+    } else if (raw_instr>>6)&0x1f != 0 {
+        return UndefinedInstr{}
+    } else if Rt == PC {
+        return MemoryHint32(instr)
+    }
+
+    return LdrshRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr LdrshRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadRegister(regs, mem, InstrFields(instr), instr.Shift, 2, true)
+}
+
+func (instr LdrshRegT2) String() string {
+    return fmt.Sprintf("ldrsh.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T3 */
+type StrImmT3 InstrFields
+
+func StrImm32T3(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrImmT3{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr StrImmT3) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == PC {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr StrImmT3) String() string {
+    return fmt.Sprintf("str.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STR (immediate)
+ * ARM ARM A7.7.158
+ * Encoding T4 */
+type StrImmT4 InstrFields
+
+func StrImm32T4(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC || !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return StrImmT4{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr StrImmT4) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == PC || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 4)
+}
+
+func (instr StrImmT4) String() string {
+    return fmt.Sprintf("str %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* STR (register)
+ * ARM ARM A7.7.159
+ * Encoding T2 */
+type StrRegT2 InstrFields
+
+func StrReg32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, Rm, shift := load_store_reg_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr StrRegT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rt == PC || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreRegister(regs, mem, InstrFields(instr), instr.Shift, 4)
+}
+
+func (instr StrRegT2) String() string {
+    return fmt.Sprintf("str.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T2 */
+type StrbImmT2 InstrFields
+
+func StrbImm32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrbImmT2{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr StrbImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr StrbImmT2) String() string {
+    return fmt.Sprintf("strb.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STRB (immediate)
+ * ARM ARM A7.7.160
+ * Encoding T3 */
+type StrbImmT3 InstrFields
+
+func StrbImm32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC || !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return StrbImmT3{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr StrbImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 1)
+}
+
+func (instr StrbImmT3) String() string {
+    return fmt.Sprintf("strb %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* STRB (register)
+ * ARM ARM A7.7.161
+ * Encoding T2 */
+type StrbRegT2 InstrFields
+
+func StrbReg32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, Rm, shift := load_store_reg_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrbRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr StrbRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreRegister(regs, mem, InstrFields(instr), instr.Shift, 1)
+}
+
+func (instr StrbRegT2) String() string {
+    return fmt.Sprintf("strb.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T2 */
+type StrhImmT2 InstrFields
+
+func StrhImm32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, imm12 := load_store_imm12_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrhImmT2{Rt: Rt, Rn: Rn, Imm: imm12, setflags: NEVER}
+}
+
+func (instr StrhImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr StrhImmT2) String() string {
+    return fmt.Sprintf("strh.w %s, [%s, #%d]", instr.Rt, instr.Rn, instr.Imm)
+}
+
+/* STRH (immediate)
+ * ARM ARM A7.7.167
+ * Encoding T3 */
+type StrhImmT3 InstrFields
+
+func StrhImm32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+    Rt, Rn, Imm, post_index, wback := load_store_imm8_fields(raw_instr)
+
+    if Rn == PC || !load_store_imm8_valid(raw_instr) {
+        return UndefinedInstr{}
+    }
+
+    return StrhImmT3{Rt: Rt, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr StrhImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || (instr.Wback && instr.Rn == instr.Rt) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreImmediate(regs, mem, InstrFields(instr), 2)
+}
+
+func (instr StrhImmT3) String() string {
+    return fmt.Sprintf("strh %s, %s", instr.Rt, indexed_operand(InstrFields(instr)))
+}
+
+/* STRH (register)
+ * ARM ARM A7.7.168
+ * Encoding T2 */
+type StrhRegT2 InstrFields
+
+func StrhReg32T2(instr FetchedInstr) DecodedInstr {
+    Rt, Rn, Rm, shift := load_store_reg_fields(instr.Uint32())
+
+    if Rn == PC {
+        return UndefinedInstr{}
+    }
+
+    return StrhRegT2{Rt: Rt, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr StrhRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return StoreRegister(regs, mem, InstrFields(instr), instr.Shift, 2)
+}
+
+func (instr StrhRegT2) String() string {
+    return fmt.Sprintf("strh.w %s, [%s, %s%s]", instr.Rt, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* LDM, LDMIA, LDMFD
+ * ARM ARM A7.7.40
+ * Encoding T1 */
+type LdmT1 InstrFields
+
+func Ldm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex((raw_instr >> 8) & 0x7)
+    RegList := RegisterList(raw_instr & 0xff)
+
+    return LdmT1{Rn: Rn, RegList: RegList, setflags: NEVER}
+}
+
+func (instr LdmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 1 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    /* The base register is only written back if it is not loaded */
+    addr := regs.R(instr.Rn)
+    wback := !instr.RegList.Contains(instr.Rn)
+
+    if err := LoadMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    if wback {
+        regs.SetR(instr.Rn, addr+4*instr.RegList.Count())
+    }
+
+    return nil
+}
+
+func (instr LdmT1) String() string {
+    if instr.RegList.Contains(instr.Rn) {
+        return fmt.Sprintf("ldm %s, %s", instr.Rn, instr.RegList)
+    }
+    return fmt.Sprintf("ldm %s!, %s", instr.Rn, instr.RegList)
+}
+
+/* STM, STMIA, STMEA
+ * ARM ARM A7.7.156
+ * Encoding T1 */
+type StmT1 InstrFields
+
+func Stm16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex((raw_instr >> 8) & 0x7)
+    RegList := RegisterList(raw_instr & 0xff)
+
+    return StmT1{Rn: Rn, RegList: RegList, setflags: NEVER}
+}
+
+func (instr StmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 1 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(instr.Rn)
+
+    if err := StoreMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    regs.SetR(instr.Rn, addr+4*instr.RegList.Count())
+
+    return nil
+}
+
+func (instr StmT1) String() string {
+    return fmt.Sprintf("stm %s!, %s", instr.Rn, instr.RegList)
+}
+
+/* PUSH
+ * ARM ARM A7.7.99
+ * Encoding T1 */
+type PushT1 InstrFields
+
+func Push16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    M := (raw_instr >> 8) & 0x1
+    RegList := RegisterList((M << LR) | (raw_instr & 0xff))
+
+    return PushT1{Rn: SP, RegList: RegList, setflags: NEVER}
+}
+
+func (instr PushT1) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 1 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(SP) - 4*instr.RegList.Count()
+
+    if err := StoreMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    regs.SetR(SP, addr)
+
+    return nil
+}
+
+func (instr PushT1) String() string {
+    return fmt.Sprintf("push %s", instr.RegList)
+}
+
+/* POP
+ * ARM ARM A7.7.98
+ * Encoding T1 */
+type PopT1 InstrFields
+
+func Pop16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    P := (raw_instr >> 8) & 0x1
+    RegList := RegisterList((P << PC) | (raw_instr & 0xff))
+
+    return PopT1{Rn: SP, RegList: RegList, setflags: NEVER}
+}
+
+func (instr PopT1) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 1 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(SP)
+
+    if err := LoadMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    regs.SetR(SP, addr+4*instr.RegList.Count())
+
+    return nil
+}
+
+func (instr PopT1) String() string {
+    return fmt.Sprintf("pop %s", instr.RegList)
+}
+
+/* Fields shared by the 32-bit loads and stores of multiple registers */
+func load_store_multiple_fields(raw_instr uint32) (Rn RegIndex, RegList RegisterList, wback bool) {
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    RegList = RegisterList(raw_instr & 0xffff)
+    wback = (raw_instr>>21)&0x1 == 1
+
+    return Rn, RegList, wback
+}
+
+/* LDM, LDMIA, LDMFD
+ * ARM ARM A7.7.40
+ * Encoding T2 */
+type LdmT2 InstrFields
+
+func Ldm32T2(instr FetchedInstr) DecodedInstr {
+    Rn, RegList, wback := load_store_multiple_fields(instr.Uint32())
+
+    if Rn == SP && wback {
+        return Pop32T2(instr)
+    }
+
+    return LdmT2{Rn: Rn, RegList: RegList, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdmT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || instr.RegList.Count() < 2 || instr.RegList.Contains(SP) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && instr.RegList.Contains(LR) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Wback && instr.RegList.Contains(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(instr.Rn)
+
+    if err := LoadMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, addr+4*instr.RegList.Count())
+    }
+
+    return nil
+}
+
+func (instr LdmT2) String() string {
+    if instr.Wback {
+        return fmt.Sprintf("ldm.w %s!, %s", instr.Rn, instr.RegList)
+    }
+    return fmt.Sprintf("ldm.w %s, %s", instr.Rn, instr.RegList)
+}
+
+/* LDMDB, LDMEA
+ * ARM ARM A7.7.41
+ * Encoding T1 */
+type LdmdbT1 InstrFields
+
+func Ldmdb32T1(instr FetchedInstr) DecodedInstr {
+    Rn, RegList, wback := load_store_multiple_fields(instr.Uint32())
+
+    return LdmdbT1{Rn: Rn, RegList: RegList, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdmdbT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || instr.RegList.Count() < 2 || instr.RegList.Contains(SP) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && instr.RegList.Contains(LR) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Wback && instr.RegList.Contains(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(instr.Rn) - 4*instr.RegList.Count()
+
+    if err := LoadMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, addr)
+    }
+
+    return nil
+}
+
+func (instr LdmdbT1) String() string {
+    if instr.Wback {
+        return fmt.Sprintf("ldmdb %s!, %s", instr.Rn, instr.RegList)
+    }
+    return fmt.Sprintf("ldmdb %s, %s", instr.Rn, instr.RegList)
+}
+
+/* STM, STMIA, STMEA
+ * ARM ARM A7.7.156
+ * Encoding T2 */
+type StmT2 InstrFields
+
+func Stm32T2(instr FetchedInstr) DecodedInstr {
+    Rn, RegList, wback := load_store_multiple_fields(instr.Uint32())
+
+    return StmT2{Rn: Rn, RegList: RegList, Wback: wback, setflags: NEVER}
+}
+
+func (instr StmT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || instr.RegList.Count() < 2 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(SP) || instr.RegList.Contains(PC) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Wback && instr.RegList.Contains(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(instr.Rn)
+
+    if err := StoreMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, addr+4*instr.RegList.Count())
+    }
+
+    return nil
+}
+
+func (instr StmT2) String() string {
+    if instr.Wback {
+        return fmt.Sprintf("stm.w %s!, %s", instr.Rn, instr.RegList)
+    }
+    return fmt.Sprintf("stm.w %s, %s", instr.Rn, instr.RegList)
+}
+
+/* STMDB, STMFD
+ * ARM ARM A7.7.157
+ * Encoding T1 */
+type StmdbT1 InstrFields
+
+func Stmdb32T1(instr FetchedInstr) DecodedInstr {
+    Rn, RegList, wback := load_store_multiple_fields(instr.Uint32())
+
+    if Rn == SP && wback {
+        return Push32T2(instr)
+    }
+
+    return StmdbT1{Rn: Rn, RegList: RegList, Wback: wback, setflags: NEVER}
+}
+
+func (instr StmdbT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn == PC || instr.RegList.Count() < 2 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(SP) || instr.RegList.Contains(PC) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Wback && instr.RegList.Contains(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(instr.Rn) - 4*instr.RegList.Count()
+
+    if err := StoreMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, addr)
+    }
+
+    return nil
+}
+
+func (instr StmdbT1) String() string {
+    if instr.Wback {
+        return fmt.Sprintf("stmdb %s!, %s", instr.Rn, instr.RegList)
+    }
+    return fmt.Sprintf("stmdb %s, %s", instr.Rn, instr.RegList)
+}
+
+/* PUSH
+ * ARM ARM A7.7.99
+ * Encoding T2
+ * The single register form, encoding T3, decodes as STR (immediate) */
+type PushT2 InstrFields
+
+func Push32T2(instr FetchedInstr) DecodedInstr {
+    _, RegList, _ := load_store_multiple_fields(instr.Uint32())
+
+    return PushT2{Rn: SP, RegList: RegList, Wback: true, setflags: NEVER}
+}
+
+func (instr PushT2) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 2 || instr.RegList.Contains(SP) || instr.RegList.Contains(PC) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(SP) - 4*instr.RegList.Count()
+
+    if err := StoreMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    regs.SetR(SP, addr)
+
+    return nil
+}
+
+func (instr PushT2) String() string {
+    return fmt.Sprintf("push.w %s", instr.RegList)
+}
+
+/* POP
+ * ARM ARM A7.7.98
+ * Encoding T2
+ * The single register form, encoding T3, decodes as LDR (immediate) */
+type PopT2 InstrFields
+
+func Pop32T2(instr FetchedInstr) DecodedInstr {
+    _, RegList, _ := load_store_multiple_fields(instr.Uint32())
+
+    return PopT2{Rn: SP, RegList: RegList, Wback: true, setflags: NEVER}
+}
+
+func (instr PopT2) Execute(regs *Registers, mem Memory) error {
+    if instr.RegList.Count() < 2 || instr.RegList.Contains(SP) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && instr.RegList.Contains(LR) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.RegList.Contains(PC) && regs.InITBlock() && !regs.LastInITBlock() {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr := regs.R(SP)
+
+    if err := LoadMultiple(regs, mem, instr.RegList, addr); err != nil {
+        return err
+    }
+
+    regs.SetR(SP, addr+4*instr.RegList.Count())
+
+    return nil
+}
+
+func (instr PopT2) String() string {
+    return fmt.Sprintf("pop.w %s", instr.RegList)
+}
+
+/* Fields shared by LDRD and STRD, with a word offset indexed as selected
+ * by P, U and W.  A subtracted offset is stored as its two's complement. */
+func load_store_dual_fields(raw_instr uint32) (Rt RegIndex, Rt2 RegIndex, Rn RegIndex, Imm uint32, post_index bool, wback bool) {
+    Rt = RegIndex((raw_instr >> 12) & 0xf)
+    Rt2 = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Imm = (raw_instr & 0xff) << 2
+
+    if (raw_instr>>23)&0x1 == 0 {
+        Imm = -Imm
+    }
+
+    post_index = (raw_instr>>24)&0x1 == 0
+    wback = (raw_instr>>21)&0x1 == 1
+
+    return Rt, Rt2, Rn, Imm, post_index, wback
+}
+
+/* LDRD (immediate)
+ * ARM ARM A7.7.49
+ * Encoding T1 */
+type LdrdImmT1 InstrFields
+
+func LdrdImm32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Rt2, Rn, Imm, post_index, wback := load_store_dual_fields(instr.Uint32())
+
+    if Rn == PC {
+        return LdrdLit32T1(instr)
+    }
+
+    return LdrdImmT1{Rt: Rt, Rt2: Rt2, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrdImmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Wback && (instr.Rn == instr.Rt || instr.Rn == instr.Rt2) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if bad_reg(instr.Rt) || bad_reg(instr.Rt2) || instr.Rt == instr.Rt2 {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr, offset_addr := indexed_address(regs, InstrFields(instr))
+
+    if err := LoadDual(regs, mem, InstrFields(instr), addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, offset_addr)
+    }
+
+    return nil
+}
+
+func (instr LdrdImmT1) String() string {
+    return fmt.Sprintf("ldrd %s, %s, %s", instr.Rt, instr.Rt2, indexed_operand(InstrFields(instr)))
+}
+
+/* LDRD (literal)
+ * ARM ARM A7.7.50
+ * Encoding T1
+ * A negative offset (U == 0) is stored as its two's complement */
+type LdrdLitT1 InstrFields
+
+func LdrdLit32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Rt2, _, Imm, _, wback := load_store_dual_fields(instr.Uint32())
+
+    return LdrdLitT1{Rt: Rt, Rt2: Rt2, Imm: Imm, Wback: wback, setflags: NEVER}
+}
+
+func (instr LdrdLitT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rt) || bad_reg(instr.Rt2) || instr.Rt == instr.Rt2 || instr.Wback {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return LoadDual(regs, mem, InstrFields(instr), align(regs.Pc(), 4)+instr.Imm)
+}
+
+func (instr LdrdLitT1) At(addr uint32) DecodedInstr {
+    instr.Addr = addr
+    return instr
+}
+
+func (instr LdrdLitT1) String() string {
+    return fmt.Sprintf("ldrd %s, %s, [pc, #%d] @ %#x", instr.Rt, instr.Rt2, int32(instr.Imm), align(instr.Addr+4, 4)+instr.Imm)
+}
+
+/* STRD (immediate)
+ * ARM ARM A7.7.163
+ * Encoding T1 */
+type StrdImmT1 InstrFields
+
+func StrdImm32T1(instr FetchedInstr) DecodedInstr {
+    Rt, Rt2, Rn, Imm, post_index, wback := load_store_dual_fields(instr.Uint32())
+
+    return StrdImmT1{Rt: Rt, Rt2: Rt2, Rn: Rn, Imm: Imm, PostIndex: post_index, Wback: wback, setflags: NEVER}
+}
+
+func (instr StrdImmT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Wback && (instr.Rn == instr.Rt || instr.Rn == instr.Rt2) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rn == PC || bad_reg(instr.Rt) || bad_reg(instr.Rt2) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    addr, offset_addr := indexed_address(regs, InstrFields(instr))
+
+    if err := StoreDual(regs, mem, InstrFields(instr), addr); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, offset_addr)
+    }
+
+    return nil
+}
+
+func (instr StrdImmT1) String() string {
+    return fmt.Sprintf("strd %s, %s, %s", instr.Rt, instr.Rt2, indexed_operand(InstrFields(instr)))
+}
@@ -0,0 +1,1475 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyLdrImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x6848), instr_valid: true},  // ldr r0, [r1, #4]
+        {instr: FetchedInstr16(0x6fff), instr_valid: true},  // ldr r7, [r7, #124]
+        {instr: FetchedInstr16(0x6048), instr_valid: false}, // str r0, [r1, #4]
+        {instr: FetchedInstr16(0x9a02), instr_valid: false}, // ldr r2, [sp, #8]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrImmT1{}))
+}
+
+func TestDecodeLdrImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr r0, [r1, #4]
+        {instr: FetchedInstr16(0x6848), decoded: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER}},
+        // ldr r7, [r7, #124]
+        {instr: FetchedInstr16(0x6fff), decoded: LdrImmT1{Rt: 7, Rn: 7, Imm: 124, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrImm16T1)
+}
+
+func TestExecuteLdrImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr r0, [r1, #4]
+        {instr: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 4}},
+            expected: Registers{r: GeneralRegs{0x0d0c0b0a, 4}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0x0a, 0x0b, 0x0c, 0x0d}},
+        // ldr r7, [r7, #4], unaligned
+        {instr: LdrImmT1{Rt: 7, Rn: 7, Imm: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{7: 1}},
+            expected: Registers{r: GeneralRegs{7: 0x0c0b0a09}},
+            mem:      Block{0, 0, 0, 0, 0, 0x09, 0x0a, 0x0b, 0x0c}},
+        // ldr r0, [r1, #4], outside of memory
+        {instr: LdrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0xdead, 4}},
+            expected: Registers{r: GeneralRegs{0xdead, 4}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0},
+            err:      BusError{Addr: 8, Size: 4, Write: false}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x9a02), instr_valid: true},  // ldr r2, [sp, #8]
+        {instr: FetchedInstr16(0x9dff), instr_valid: true},  // ldr r5, [sp, #1020]
+        {instr: FetchedInstr16(0x9202), instr_valid: false}, // str r2, [sp, #8]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrImmT2{}))
+}
+
+func TestDecodeLdrImm16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr r2, [sp, #8]
+        {instr: FetchedInstr16(0x9a02), decoded: LdrImmT2{Rt: 2, Rn: SP, Imm: 8, setflags: NEVER}},
+        // ldr r5, [sp, #1020]
+        {instr: FetchedInstr16(0x9dff), decoded: LdrImmT2{Rt: 5, Rn: SP, Imm: 1020, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrImm16T2)
+}
+
+func TestExecuteLdrImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr r2, [sp, #8]
+        {instr: LdrImmT2{Rt: 2, Rn: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{4, 0}},
+            expected: Registers{r: GeneralRegs{2: 0x44332211}, sp: SPRegs{4, 0}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr r2, [sp, #8], from the process stack
+        {instr: LdrImmT2{Rt: 2, Rn: SP, Imm: 8, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0, 4}, Control: Control{Spsel: PSP}},
+            expected: Registers{r: GeneralRegs{2: 0x44332211}, sp: SPRegs{0, 4}, Control: Control{Spsel: PSP}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrLitT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4802), instr_valid: true},  // ldr r0, [pc, #8]
+        {instr: FetchedInstr16(0x4fff), instr_valid: true},  // ldr r7, [pc, #1020]
+        {instr: FetchedInstr16(0x4478), instr_valid: false}, // add r0, pc
+        {instr: FetchedInstr16(0xa101), instr_valid: false}, // adr r1, #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrLitT1{}))
+}
+
+func TestDecodeLdrLit16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr r0, [pc, #8]
+        {instr: FetchedInstr16(0x4802), decoded: LdrLitT1{Rt: 0, Imm: 8, setflags: NEVER}},
+        // ldr r7, [pc, #1020]
+        {instr: FetchedInstr16(0x4fff), decoded: LdrLitT1{Rt: 7, Imm: 1020, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrLit16T1)
+}
+
+func TestExecuteLdrLitT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr r0, [pc, #4], at address 0
+        {instr: LdrLitT1{Rt: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x4},
+            expected: Registers{r: GeneralRegs{0x44332211}, pc: 0x4},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr r0, [pc, #4], at address 2, from the same aligned base
+        {instr: LdrLitT1{Rt: 0, Imm: 4, setflags: NEVER},
+            regs:     Registers{pc: 0x6},
+            expected: Registers{r: GeneralRegs{0x44332211}, pc: 0x6},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrLitT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf8df0008), instr_valid: true}, // ldr.w r0, [pc, #8]
+        {instr: FetchedInstr32(0xf85fc008), instr_valid: true}, // ldr.w r12, [pc, #-8]
+        {instr: FetchedInstr32(0xf8dffffc), instr_valid: true}, // ldr.w pc, [pc, #4092]
+        {instr: FetchedInstr16(0x4802), instr_valid: false},    // ldr r0, [pc, #8]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrLitT2{}))
+}
+
+func TestDecodeLdrLit32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr.w r0, [pc, #8]
+        {instr: FetchedInstr32(0xf8df0008), decoded: LdrLitT2{Rt: 0, Imm: 8, setflags: NEVER}},
+        // ldr.w r12, [pc, #-8]
+        {instr: FetchedInstr32(0xf85fc008), decoded: LdrLitT2{Rt: 12, Imm: 0xfffffff8, setflags: NEVER}},
+        // ldr.w pc, [pc, #4092]
+        {instr: FetchedInstr32(0xf8dffffc), decoded: LdrLitT2{Rt: PC, Imm: 4092, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrLit32T2)
+}
+
+func TestExecuteLdrLitT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr.w r12, [pc, #-8]
+        {instr: LdrLitT2{Rt: 12, Imm: 0xfffffff8, setflags: NEVER},
+            regs:     Registers{pc: 0xe},
+            expected: Registers{r: GeneralRegs{12: 0x44332211}, pc: 0xe},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr.w pc, [pc, #0]
+        {instr: LdrLitT2{Rt: PC, Imm: 0, setflags: NEVER},
+            regs:     Registers{pc: 0x4, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0x100, branched: true, Epsr: Epsr{T: true}},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x01, 0, 0}},
+        // ldr.w pc, [pc, #0], to an even address
+        {instr: LdrLitT2{Rt: PC, Imm: 0, setflags: NEVER},
+            regs:     Registers{pc: 0x4, Epsr: Epsr{T: true}},
+            expected: Registers{pc: 0x100, branched: true, Epsr: Epsr{T: false}},
+            mem:      Block{0, 0, 0, 0, 0x00, 0x01, 0, 0}},
+        // ldr.w pc, [pc, #0], not last in an IT block (UNPREDICTABLE)
+        {instr: LdrLitT2{Rt: PC, Imm: 0, setflags: NEVER},
+            regs:     Registers{pc: 0x4, Epsr: Epsr{T: true, IT: 0x04}},
+            expected: Registers{pc: 0x4, Epsr: Epsr{T: true, IT: 0x04}},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x01, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringLiteralTarget(t *testing.T) {
+    cases := []struct {
+        instr    DecodedInstr
+        addr     uint32
+        expected string
+    }{
+        {instr: LdrLitT1{Rt: 0, Imm: 8, setflags: NEVER}, addr: 0x102, expected: "ldr r0, [pc, #8] @ 0x10c"},
+        {instr: LdrLitT2{Rt: 12, Imm: 0xfffffff8, setflags: NEVER}, addr: 0x100, expected: "ldr.w r12, [pc, #-8] @ 0xfc"},
+        {instr: LdrbLitT1{Rt: 3, Imm: 0xfffffffc, setflags: NEVER}, addr: 0x102, expected: "ldrb.w r3, [pc, #-4] @ 0x100"},
+        {instr: LdrdLitT1{Rt: 0, Rt2: 1, Imm: 8, setflags: NEVER}, addr: 0x100, expected: "ldrd r0, r1, [pc, #8] @ 0x10c"},
+        {instr: PldLitT1{Imm: 8, setflags: NEVER}, addr: 0x102, expected: "pld [pc, #8] @ 0x10c"},
+        {instr: PliLitT3{Imm: 0xfffffff8, setflags: NEVER}, addr: 0x100, expected: "pli [pc, #-8] @ 0xfc"},
+        {instr: AdrT1{Rd: 1, Imm: 4, setflags: NEVER}, addr: 0x106, expected: "adr r1, #4 @ 0x10c"},
+        {instr: AdrT2{Rd: 9, Imm: 0xfffff001, setflags: NEVER}, addr: 0x1ffe, expected: "adr.w r9, #-4095 @ 0x1001"},
+        {instr: MovImm{Rd: 0, Imm: 1, setflags: NOT_IT}, addr: 0x100, expected: "movs r0, #0x1"},
+    }
+
+    for _, test := range cases {
+        located := Locate(test.instr, test.addr)
+        if actual := located.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#v at %#x: %q, expected %q", test.instr, test.addr, actual, test.expected)
+        }
+    }
+}
+
+func TestIdentifyLdrbImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x7fe3), instr_valid: true},  // ldrb r3, [r4, #31]
+        {instr: FetchedInstr16(0x7800), instr_valid: true},  // ldrb r0, [r0]
+        {instr: FetchedInstr16(0x77e3), instr_valid: false}, // strb r3, [r4, #31]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrbImmT1{}))
+}
+
+func TestDecodeLdrbImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrb r3, [r4, #31]
+        {instr: FetchedInstr16(0x7fe3), decoded: LdrbImmT1{Rt: 3, Rn: 4, Imm: 31, setflags: NEVER}},
+        // ldrb r0, [r0]
+        {instr: FetchedInstr16(0x7800), decoded: LdrbImmT1{Rt: 0, Rn: 0, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrbImm16T1)
+}
+
+func TestExecuteLdrbImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldrb r3, [r4, #1], zero extended
+        {instr: LdrbImmT1{Rt: 3, Rn: 4, Imm: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{3: 0xffffffff, 4: 2}},
+            expected: Registers{r: GeneralRegs{3: 0xfe, 4: 2}},
+            mem:      Block{0, 0, 0, 0xfe, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrhImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x8fd1), instr_valid: true},  // ldrh r1, [r2, #62]
+        {instr: FetchedInstr16(0x886e), instr_valid: true},  // ldrh r6, [r5, #2]
+        {instr: FetchedInstr16(0x87d1), instr_valid: false}, // strh r1, [r2, #62]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrhImmT1{}))
+}
+
+func TestDecodeLdrhImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrh r1, [r2, #62]
+        {instr: FetchedInstr16(0x8fd1), decoded: LdrhImmT1{Rt: 1, Rn: 2, Imm: 62, setflags: NEVER}},
+        // ldrh r6, [r5, #2]
+        {instr: FetchedInstr16(0x886e), decoded: LdrhImmT1{Rt: 6, Rn: 5, Imm: 2, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrhImm16T1)
+}
+
+func TestExecuteLdrhImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldrh r6, [r5, #2], zero extended
+        {instr: LdrhImmT1{Rt: 6, Rn: 5, Imm: 2, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{5: 2, 6: 0xffffffff}},
+            expected: Registers{r: GeneralRegs{5: 2, 6: 0x8001}},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x80}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x6048), instr_valid: true},  // str r0, [r1, #4]
+        {instr: FetchedInstr16(0x67f7), instr_valid: true},  // str r7, [r6, #124]
+        {instr: FetchedInstr16(0x6848), instr_valid: false}, // ldr r0, [r1, #4]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrImmT1{}))
+}
+
+func TestDecodeStrImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // str r0, [r1, #4]
+        {instr: FetchedInstr16(0x6048), decoded: StrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER}},
+        // str r7, [r6, #124]
+        {instr: FetchedInstr16(0x67f7), decoded: StrImmT1{Rt: 7, Rn: 6, Imm: 124, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrImm16T1)
+}
+
+func TestExecuteStrImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // str r0, [r1, #4]
+        {instr: StrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x12345678, 2}},
+            expected:     Registers{r: GeneralRegs{0x12345678, 2}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0x78, 0x56, 0x34, 0x12}},
+        // str r0, [r1, #4], outside of memory
+        {instr: StrImmT1{Rt: 0, Rn: 1, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x12345678, 2}},
+            expected:     Registers{r: GeneralRegs{0x12345678, 2}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0},
+            err:          BusError{Addr: 6, Size: 4, Write: true}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x9202), instr_valid: true},  // str r2, [sp, #8]
+        {instr: FetchedInstr16(0x95ff), instr_valid: true},  // str r5, [sp, #1020]
+        {instr: FetchedInstr16(0x9a02), instr_valid: false}, // ldr r2, [sp, #8]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrImmT2{}))
+}
+
+func TestDecodeStrImm16T2(t *testing.T) {
+    cases := []DecodeCase{
+        // str r2, [sp, #8]
+        {instr: FetchedInstr16(0x9202), decoded: StrImmT2{Rt: 2, Rn: SP, Imm: 8, setflags: NEVER}},
+        // str r5, [sp, #1020]
+        {instr: FetchedInstr16(0x95ff), decoded: StrImmT2{Rt: 5, Rn: SP, Imm: 1020, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrImm16T2)
+}
+
+func TestExecuteStrImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // str r2, [sp, #4]
+        {instr: StrImmT2{Rt: 2, Rn: SP, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{2: 0xcafef00d}, sp: SPRegs{4, 0}},
+            expected:     Registers{r: GeneralRegs{2: 0xcafef00d}, sp: SPRegs{4, 0}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0, 0x0d, 0xf0, 0xfe, 0xca}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrbImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x77e3), instr_valid: true},  // strb r3, [r4, #31]
+        {instr: FetchedInstr16(0x7fe3), instr_valid: false}, // ldrb r3, [r4, #31]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrbImmT1{}))
+}
+
+func TestDecodeStrbImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // strb r3, [r4, #31]
+        {instr: FetchedInstr16(0x77e3), decoded: StrbImmT1{Rt: 3, Rn: 4, Imm: 31, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrbImm16T1)
+}
+
+func TestExecuteStrbImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // strb r3, [r4, #1], only the low byte
+        {instr: StrbImmT1{Rt: 3, Rn: 4, Imm: 1, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{3: 0x123456ab, 4: 2}},
+            expected:     Registers{r: GeneralRegs{3: 0x123456ab, 4: 2}},
+            mem:          Block{0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0xab, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrhImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x87d1), instr_valid: true},  // strh r1, [r2, #62]
+        {instr: FetchedInstr16(0x8fd1), instr_valid: false}, // ldrh r1, [r2, #62]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrhImmT1{}))
+}
+
+func TestDecodeStrhImm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // strh r1, [r2, #62]
+        {instr: FetchedInstr16(0x87d1), decoded: StrhImmT1{Rt: 1, Rn: 2, Imm: 62, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrhImm16T1)
+}
+
+func TestExecuteStrhImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // strh r1, [r2, #2], only the low halfword
+        {instr: StrhImmT1{Rt: 1, Rn: 2, Imm: 2, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{1: 0x1234abcd, 2: 2}},
+            expected:     Registers{r: GeneralRegs{1: 0x1234abcd, 2: 2}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0xcd, 0xab, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5888), instr_valid: true},  // ldr r0, [r1, r2]
+        {instr: FetchedInstr16(0x5977), instr_valid: true},  // ldr r7, [r6, r5]
+        {instr: FetchedInstr16(0x5088), instr_valid: false}, // str r0, [r1, r2]
+        {instr: FetchedInstr16(0x6848), instr_valid: false}, // ldr r0, [r1, #4]
+        {instr: FetchedInstr16(0xffff), instr_valid: false},
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrRegT1{}))
+}
+
+func TestDecodeLdrReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr r0, [r1, r2]
+        {instr: FetchedInstr16(0x5888), decoded: LdrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER}},
+        // ldr r7, [r6, r5]
+        {instr: FetchedInstr16(0x5977), decoded: LdrRegT1{Rt: 7, Rm: 5, Rn: 6, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrReg16T1)
+}
+
+func TestExecuteLdrRegT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88}
+
+    cases := []ExecuteCase{
+        // ldr r0, [r1, r2]
+        {instr: LdrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x88878685, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldr r7, [r6, r5]
+        {instr: LdrRegT1{Rt: 7, Rm: 5, Rn: 6, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xfffffffe, 2, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xfffffffe, 2, 0x04030201, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldr r0, [r1, r2], unaligned
+        {instr: LdrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x85040302, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldr r0, [r1, r2], outside of memory
+        {instr: LdrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 4, 4, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 4, 4, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem,
+            err:      BusError{Addr: 8, Size: 4, Write: false}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrbRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5cd1), instr_valid: true},  // ldrb r1, [r2, r3]
+        {instr: FetchedInstr16(0x54d1), instr_valid: false}, // strb r1, [r2, r3]
+        {instr: FetchedInstr16(0x5763), instr_valid: false}, // ldrsb r3, [r4, r5]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrbRegT1{}))
+}
+
+func TestDecodeLdrbReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrb r1, [r2, r3]
+        {instr: FetchedInstr16(0x5cd1), decoded: LdrbRegT1{Rt: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrbReg16T1)
+}
+
+func TestExecuteLdrbRegT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88}
+
+    cases := []ExecuteCase{
+        // ldrb r1, [r2, r3]
+        {instr: LdrbRegT1{Rt: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0x85, 2, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldrb r1, [r2, r3]
+        {instr: LdrbRegT1{Rt: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 1, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0x04, 2, 1, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrhRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5b1a), instr_valid: true},  // ldrh r2, [r3, r4]
+        {instr: FetchedInstr16(0x531a), instr_valid: false}, // strh r2, [r3, r4]
+        {instr: FetchedInstr16(0x5fac), instr_valid: false}, // ldrsh r4, [r5, r6]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrhRegT1{}))
+}
+
+func TestDecodeLdrhReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrh r2, [r3, r4]
+        {instr: FetchedInstr16(0x5b1a), decoded: LdrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrhReg16T1)
+}
+
+func TestExecuteLdrhRegT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88}
+
+    cases := []ExecuteCase{
+        // ldrh r2, [r3, r4]
+        {instr: LdrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x8887, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldrh r2, [r3, r4]
+        {instr: LdrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0, 0, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x0201, 0, 0, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrsbRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5763), instr_valid: true},  // ldrsb r3, [r4, r5]
+        {instr: FetchedInstr16(0x5cd1), instr_valid: false}, // ldrb r1, [r2, r3]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrsbRegT1{}))
+}
+
+func TestDecodeLdrsbReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrsb r3, [r4, r5]
+        {instr: FetchedInstr16(0x5763), decoded: LdrsbRegT1{Rt: 3, Rm: 5, Rn: 4, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrsbReg16T1)
+}
+
+func TestExecuteLdrsbRegT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88}
+
+    cases := []ExecuteCase{
+        // ldrsb r3, [r4, r5], negative
+        {instr: LdrsbRegT1{Rt: 3, Rm: 5, Rn: 4, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xffffff85, 4, 0, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldrsb r3, [r4, r5], positive
+        {instr: LdrsbRegT1{Rt: 3, Rm: 5, Rn: 4, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xfffffffd, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x02, 4, 0xfffffffd, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLdrshRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5fac), instr_valid: true},  // ldrsh r4, [r5, r6]
+        {instr: FetchedInstr16(0x5b1a), instr_valid: false}, // ldrh r2, [r3, r4]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdrshRegT1{}))
+}
+
+func TestDecodeLdrshReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrsh r4, [r5, r6]
+        {instr: FetchedInstr16(0x5fac), decoded: LdrshRegT1{Rt: 4, Rm: 6, Rn: 5, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrshReg16T1)
+}
+
+func TestExecuteLdrshRegT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88}
+
+    cases := []ExecuteCase{
+        // ldrsh r4, [r5, r6], negative
+        {instr: LdrshRegT1{Rt: 4, Rm: 6, Rn: 5, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 2, 2, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffff8685, 2, 2, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldrsh r4, [r5, r6], positive
+        {instr: LdrshRegT1{Rt: 4, Rm: 6, Rn: 5, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 2, 0, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x0403, 2, 0, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x5088), instr_valid: true},  // str r0, [r1, r2]
+        {instr: FetchedInstr16(0x51ff), instr_valid: true},  // str r7, [r7, r7]
+        {instr: FetchedInstr16(0x5888), instr_valid: false}, // ldr r0, [r1, r2]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrRegT1{}))
+}
+
+func TestDecodeStrReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // str r0, [r1, r2]
+        {instr: FetchedInstr16(0x5088), decoded: StrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER}},
+        // str r7, [r7, r7]
+        {instr: FetchedInstr16(0x51ff), decoded: StrRegT1{Rt: 7, Rm: 7, Rn: 7, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrReg16T1)
+}
+
+func TestExecuteStrRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // str r0, [r1, r2]
+        {instr: StrRegT1{Rt: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x11223344, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0x11223344, 1, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0x44, 0x33, 0x22, 0x11}},
+        // str r7, [r7, r7]
+        {instr: StrRegT1{Rt: 7, Rm: 7, Rn: 7, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 2, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 2, 8, 9, 10, 11, 12}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0x02, 0, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrbRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x54d1), instr_valid: true},  // strb r1, [r2, r3]
+        {instr: FetchedInstr16(0x5cd1), instr_valid: false}, // ldrb r1, [r2, r3]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrbRegT1{}))
+}
+
+func TestDecodeStrbReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // strb r1, [r2, r3]
+        {instr: FetchedInstr16(0x54d1), decoded: StrbRegT1{Rt: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrbReg16T1)
+}
+
+func TestExecuteStrbRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // strb r1, [r2, r3]
+        {instr: StrbRegT1{Rt: 1, Rm: 3, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0xaabbccdd, 4, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0, 0xaabbccdd, 4, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0xdd}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStrhRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x531a), instr_valid: true},  // strh r2, [r3, r4]
+        {instr: FetchedInstr16(0x5b1a), instr_valid: false}, // ldrh r2, [r3, r4]
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StrhRegT1{}))
+}
+
+func TestDecodeStrhReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // strh r2, [r3, r4]
+        {instr: FetchedInstr16(0x531a), decoded: StrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, StrhReg16T1)
+}
+
+func TestExecuteStrhRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // strh r2, [r3, r4]
+        {instr: StrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 1, 0xaabbccdd, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0, 1, 0xaabbccdd, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0xdd, 0xcc}},
+        // strh r2, [r3, r4], outside of memory
+        {instr: StrhRegT1{Rt: 2, Rm: 4, Rn: 3, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 1, 0xaabbccdd, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0, 1, 0xaabbccdd, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0},
+            err:          BusError{Addr: 7, Size: 2, Write: true}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestRegisterListString(t *testing.T) {
+    cases := []struct {
+        list     RegisterList
+        expected string
+    }{
+        {list: 0x0000, expected: "{}"},
+        {list: 0x4003, expected: "{r0, r1, lr}"},
+        {list: 0xa010, expected: "{r4, sp, pc}"},
+    }
+
+    for _, test := range cases {
+        if actual := test.list.String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", uint16(test.list), actual, test.expected)
+        }
+    }
+}
+
+func TestIdentifyLdmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xc806), instr_valid: true},  // ldm r0!, {r1, r2}
+        {instr: FetchedInstr16(0xc903), instr_valid: true},  // ldm r1, {r0, r1}
+        {instr: FetchedInstr16(0xc289), instr_valid: false}, // stm r2!, {r0, r3, r7}
+        {instr: FetchedInstr16(0xbd03), instr_valid: false}, // pop {r0, r1, pc}
+    }
+
+    test_identify(t, cases, reflect.TypeOf(LdmT1{}))
+}
+
+func TestDecodeLdm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldm r0!, {r1, r2}
+        {instr: FetchedInstr16(0xc806), decoded: LdmT1{Rn: 0, RegList: 0x0006, setflags: NEVER}},
+        // ldm r1, {r0, r1}
+        {instr: FetchedInstr16(0xc903), decoded: LdmT1{Rn: 1, RegList: 0x0003, setflags: NEVER}},
+        // ldm r7, {r7}
+        {instr: FetchedInstr16(0xcf80), decoded: LdmT1{Rn: 7, RegList: 0x0080, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Ldm16T1)
+}
+
+func TestExecuteLdmT1(t *testing.T) {
+    mem := Block{0x01, 0x02, 0x03, 0x04, 0x85, 0x86, 0x87, 0x88, 0x11, 0x22, 0x33, 0x44}
+
+    cases := []ExecuteCase{
+        // ldm r0!, {r1, r2}
+        {instr: LdmT1{Rn: 0, RegList: 0x0006, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{12, 0x88878685, 0x44332211, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldm r1, {r0, r1}, without writeback
+        {instr: LdmT1{Rn: 1, RegList: 0x0003, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x04030201, 0x88878685, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+        // ldm r0!, {r1, r2}, unaligned
+        {instr: LdmT1{Rn: 0, RegList: 0x0006, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem,
+            err:      UFSR_UNALIGNED},
+        // ldm r0!, {r1, r2}, partly outside of memory
+        {instr: LdmT1{Rn: 0, RegList: 0x0006, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{8, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{8, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem,
+            err:      BusError{Addr: 12, Size: 4, Write: false}},
+        // ldm r0!, {} (UNPREDICTABLE)
+        {instr: LdmT1{Rn: 0, RegList: 0x0000, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyStmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xc289), instr_valid: true},  // stm r2!, {r0, r3, r7}
+        {instr: FetchedInstr16(0xc806), instr_valid: false}, // ldm r0!, {r1, r2}
+        {instr: FetchedInstr16(0xb503), instr_valid: false}, // push {r0, r1, lr}
+    }
+
+    test_identify(t, cases, reflect.TypeOf(StmT1{}))
+}
+
+func TestDecodeStm16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // stm r2!, {r0, r3, r7}
+        {instr: FetchedInstr16(0xc289), decoded: StmT1{Rn: 2, RegList: 0x0089, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Stm16T1)
+}
+
+func TestExecuteStmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // stm r2!, {r0, r3, r7}
+        {instr: StmT1{Rn: 2, RegList: 0x0089, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x10, 1, 4, 0x13, 4, 5, 6, 0x17, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0x10, 1, 16, 0x13, 4, 5, 6, 0x17, 8, 9, 10, 11, 12}},
+            mem:          make(Block, 16),
+            expected_mem: Block{0, 0, 0, 0, 0x10, 0, 0, 0, 0x13, 0, 0, 0, 0x17, 0, 0, 0}},
+        // stm r2!, {r0, r3, r7}, unaligned
+        {instr: StmT1{Rn: 2, RegList: 0x0089, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x10, 1, 6, 0x13, 4, 5, 6, 0x17, 8, 9, 10, 11, 12}},
+            expected:     Registers{r: GeneralRegs{0x10, 1, 6, 0x13, 4, 5, 6, 0x17, 8, 9, 10, 11, 12}},
+            mem:          make(Block, 16),
+            expected_mem: make(Block, 16),
+            err:          UFSR_UNALIGNED},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyPushT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xb503), instr_valid: true},  // push {r0, r1, lr}
+        {instr: FetchedInstr16(0xb410), instr_valid: true},  // push {r4}
+        {instr: FetchedInstr16(0xbd03), instr_valid: false}, // pop {r0, r1, pc}
+    }
+
+    test_identify(t, cases, reflect.TypeOf(PushT1{}))
+}
+
+func TestDecodePush16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // push {r0, r1, lr}
+        {instr: FetchedInstr16(0xb503), decoded: PushT1{Rn: SP, RegList: 0x4003, setflags: NEVER}},
+        // push {r4}
+        {instr: FetchedInstr16(0xb410), decoded: PushT1{Rn: SP, RegList: 0x0010, setflags: NEVER}},
+        // push {r0, r1, r2, r3, r4, r5, r6, r7, lr}
+        {instr: FetchedInstr16(0xb5ff), decoded: PushT1{Rn: SP, RegList: 0x40ff, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Push16T1)
+}
+
+func TestExecutePushT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // push {r0, r1, lr}
+        {instr: PushT1{Rn: SP, RegList: 0x4003, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x10, 0x11}, sp: SPRegs{16, 0}, lr: 0x1e},
+            expected:     Registers{r: GeneralRegs{0x10, 0x11}, sp: SPRegs{4, 0}, lr: 0x1e},
+            mem:          make(Block, 16),
+            expected_mem: Block{0, 0, 0, 0, 0x10, 0, 0, 0, 0x11, 0, 0, 0, 0x1e, 0, 0, 0}},
+        // push {r4}, on the process stack
+        {instr: PushT1{Rn: SP, RegList: 0x0010, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{4: 0xaabbccdd}, sp: SPRegs{16, 8}, Control: Control{Spsel: PSP}},
+            expected:     Registers{r: GeneralRegs{4: 0xaabbccdd}, sp: SPRegs{16, 4}, Control: Control{Spsel: PSP}},
+            mem:          make(Block, 16),
+            expected_mem: Block{0, 0, 0, 0, 0xdd, 0xcc, 0xbb, 0xaa, 0, 0, 0, 0, 0, 0, 0, 0}},
+        // push {r4}, stack overflow
+        {instr: PushT1{Rn: SP, RegList: 0x0010, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{4: 0xaabbccdd}, sp: SPRegs{0, 0}},
+            expected:     Registers{r: GeneralRegs{4: 0xaabbccdd}, sp: SPRegs{0, 0}},
+            mem:          make(Block, 16),
+            expected_mem: make(Block, 16),
+            err:          BusError{Addr: 0xfffffffc, Size: 4, Write: true}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyPopT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0xbd03), instr_valid: true},  // pop {r0, r1, pc}
+        {instr: FetchedInstr16(0xbc10), instr_valid: true},  // pop {r4}
+        {instr: FetchedInstr16(0xb503), instr_valid: false}, // push {r0, r1, lr}
+        {instr: FetchedInstr16(0xc903), instr_valid: false}, // ldm r1, {r0, r1}
+    }
+
+    test_identify(t, cases, reflect.TypeOf(PopT1{}))
+}
+
+func TestDecodePop16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // pop {r0, r1, pc}
+        {instr: FetchedInstr16(0xbd03), decoded: PopT1{Rn: SP, RegList: 0x8003, setflags: NEVER}},
+        // pop {r4}
+        {instr: FetchedInstr16(0xbc10), decoded: PopT1{Rn: SP, RegList: 0x0010, setflags: NEVER}},
+        // pop {r0, r1, r2, r3, r4, r5, r6, r7, pc}
+        {instr: FetchedInstr16(0xbdff), decoded: PopT1{Rn: SP, RegList: 0x80ff, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Pop16T1)
+}
+
+func TestExecutePopT1(t *testing.T) {
+    mem := Block{0x10, 0, 0, 0, 0x11, 0, 0, 0, 0x01, 0x02, 0, 0, 0x00, 0x03, 0, 0}
+
+    cases := []ExecuteCase{
+        // pop {r0, r1, pc}
+        {instr: PopT1{Rn: SP, RegList: 0x8003, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{0x10, 0x11}, sp: SPRegs{12, 0}, pc: 0x200, branched: true, Epsr: Epsr{T: true}},
+            mem:      mem},
+        // pop {pc}, to an even address
+        {instr: PopT1{Rn: SP, RegList: 0x8000, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{12, 0}, pc: 0x104, Epsr: Epsr{T: true}},
+            expected: Registers{sp: SPRegs{16, 0}, pc: 0x300, branched: true, Epsr: Epsr{T: false}},
+            mem:      mem},
+        // pop {r4}
+        {instr: PopT1{Rn: SP, RegList: 0x0010, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{4, 0}},
+            expected: Registers{r: GeneralRegs{4: 0x11}, sp: SPRegs{8, 0}},
+            mem:      mem},
+        // pop {r0, r1, pc}, not last in an IT block (UNPREDICTABLE)
+        {instr: PopT1{Rn: SP, RegList: 0x8003, setflags: NEVER},
+            regs:     Registers{pc: 0x104, Epsr: Epsr{T: true, IT: 0x04}},
+            expected: Registers{pc: 0x104, Epsr: Epsr{T: true, IT: 0x04}},
+            mem:      mem},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLoadStore32(t *testing.T) {
+    cases := []struct {
+        instr      FetchedInstr32
+        instr_type reflect.Type
+    }{
+        {instr: 0xf8d10004, instr_type: reflect.TypeOf(LdrImmT3{})},           // ldr.w r0, [r1, #4]
+        {instr: 0xf8510c04, instr_type: reflect.TypeOf(LdrImmT4{})},           // ldr r0, [r1, #-4]
+        {instr: 0xf8510f04, instr_type: reflect.TypeOf(LdrImmT4{})},           // ldr r0, [r1, #4]!
+        {instr: 0xf8510022, instr_type: reflect.TypeOf(LdrRegT2{})},           // ldr.w r0, [r1, r2, lsl #2]
+        {instr: 0xf85f0c04, instr_type: reflect.TypeOf(LdrLitT2{})},           // ldr.w r0, [pc, #-3076]
+        {instr: 0xf8810004, instr_type: reflect.TypeOf(StrbImmT2{})},          // strb.w r0, [r1, #4]
+        {instr: 0xf8010c04, instr_type: reflect.TypeOf(StrbImmT3{})},          // strb r0, [r1, #-4]
+        {instr: 0xf8210012, instr_type: reflect.TypeOf(StrhRegT2{})},          // strh.w r0, [r1, r2, lsl #1]
+        {instr: 0xf84d0d04, instr_type: reflect.TypeOf(StrImmT4{})},           // str r0, [sp, #-4]!
+        {instr: 0xf9910004, instr_type: reflect.TypeOf(LdrsbImmT1{})},         // ldrsb.w r0, [r1, #4]
+        {instr: 0xf9110b04, instr_type: reflect.TypeOf(LdrsbImmT2{})},         // ldrsb r0, [r1], #4
+        {instr: 0xf9310d02, instr_type: reflect.TypeOf(LdrshImmT2{})},         // ldrsh r0, [r1, #-2]!
+        {instr: 0xf89f0004, instr_type: reflect.TypeOf(LdrbLitT1{})},          // ldrb.w r0, [pc, #4]
+        {instr: 0xf81f0004, instr_type: reflect.TypeOf(LdrbLitT1{})},          // ldrb.w r0, [pc, #-4]
+        {instr: 0xf9bf0004, instr_type: reflect.TypeOf(LdrshLitT1{})},         // ldrsh.w r0, [pc, #4]
+        {instr: 0xe9d20102, instr_type: reflect.TypeOf(LdrdImmT1{})},          // ldrd r0, r1, [r2, #8]
+        {instr: 0xe9720102, instr_type: reflect.TypeOf(LdrdImmT1{})},          // ldrd r0, r1, [r2, #-8]!
+        {instr: 0xe8e20102, instr_type: reflect.TypeOf(StrdImmT1{})},          // strd r0, r1, [r2], #8
+        {instr: 0xe9df0102, instr_type: reflect.TypeOf(LdrdLitT1{})},          // ldrd r0, r1, [pc, #8]
+        {instr: 0xf8510e04, instr_type: reflect.TypeOf(UndefinedInstr{})},     // ldrt r0, [r1, #4]
+        {instr: 0xf8510804, instr_type: reflect.TypeOf(UndefinedInstr{})},     // P and W both clear
+        {instr: 0xf891f004, instr_type: reflect.TypeOf(PldImmT1{})},           // pld [r1, #4]
+        {instr: 0xf811fc04, instr_type: reflect.TypeOf(PldImmT2{})},           // pld [r1, #-4]
+        {instr: 0xf89ff008, instr_type: reflect.TypeOf(PldLitT1{})},           // pld [pc, #8]
+        {instr: 0xf81ff008, instr_type: reflect.TypeOf(PldLitT1{})},           // pld [pc, #-8]
+        {instr: 0xf811f022, instr_type: reflect.TypeOf(PldRegT1{})},           // pld [r1, r2, lsl #2]
+        {instr: 0xf991f004, instr_type: reflect.TypeOf(PliImmT1{})},           // pli [r1, #4]
+        {instr: 0xf911fc04, instr_type: reflect.TypeOf(PliImmT2{})},           // pli [r1, #-4]
+        {instr: 0xf99ff008, instr_type: reflect.TypeOf(PliLitT3{})},           // pli [pc, #8]
+        {instr: 0xf911f002, instr_type: reflect.TypeOf(PliRegT1{})},           // pli [r1, r2]
+        {instr: 0xf8b1f004, instr_type: reflect.TypeOf(UnallocatedHint{})},    // ldrh.w pc, [r1, #4]
+        {instr: 0xf831fc04, instr_type: reflect.TypeOf(UnallocatedHint{})},    // ldrh pc, [r1, #-4]
+        {instr: 0xf9bff004, instr_type: reflect.TypeOf(UnallocatedHint{})},    // ldrsh.w pc, [pc, #4]
+        {instr: 0xf931f002, instr_type: reflect.TypeOf(UnallocatedHint{})},    // ldrsh.w pc, [r1, r2]
+        {instr: 0xf811fe04, instr_type: reflect.TypeOf(UnpredictableInstr{})}, // ldrbt pc, [r1, #4]
+        {instr: 0xf831fe04, instr_type: reflect.TypeOf(UnpredictableInstr{})}, // ldrht pc, [r1, #4]
+        {instr: 0xf811f804, instr_type: reflect.TypeOf(UndefinedInstr{})},     // P and W both clear
+    }
+
+    for _, test := range cases {
+        instr, _ := test.instr.Decode()
+        if reflect.TypeOf(instr) != test.instr_type {
+            t.Errorf("%#x: %T, expected %v", uint32(test.instr), instr, test.instr_type)
+        }
+    }
+}
+
+func TestDecodeLdrImm32T4(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr r0, [r1, #-4]
+        {instr: FetchedInstr32(0xf8510c04), decoded: LdrImmT4{Rt: 0, Rn: 1, Imm: 0xfffffffc, PostIndex: false, Wback: false, setflags: NEVER}},
+        // ldr r0, [r1, #4]!
+        {instr: FetchedInstr32(0xf8510f04), decoded: LdrImmT4{Rt: 0, Rn: 1, Imm: 4, PostIndex: false, Wback: true, setflags: NEVER}},
+        // ldr r0, [r1], #-4
+        {instr: FetchedInstr32(0xf8510904), decoded: LdrImmT4{Rt: 0, Rn: 1, Imm: 0xfffffffc, PostIndex: true, Wback: true, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrImm32T4)
+}
+
+func TestDecodeLdrReg32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // ldr.w r0, [r1, r2, lsl #2]
+        {instr: FetchedInstr32(0xf8510022), decoded: LdrRegT2{Rt: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER}},
+        // ldr.w r0, [pc, #-34], which shares the encoding space
+        {instr: FetchedInstr32(0xf85f0022), decoded: LdrLitT2{Rt: 0, Imm: 0xffffffde, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrReg32T2)
+}
+
+func TestDecodeLdrdImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ldrd r0, r1, [r2, #8]
+        {instr: FetchedInstr32(0xe9d20102), decoded: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, PostIndex: false, Wback: false, setflags: NEVER}},
+        // ldrd r0, r1, [r2, #-8]!
+        {instr: FetchedInstr32(0xe9720102), decoded: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 0xfffffff8, PostIndex: false, Wback: true, setflags: NEVER}},
+        // ldrd r0, r1, [pc, #8]
+        {instr: FetchedInstr32(0xe9df0102), decoded: LdrdLitT1{Rt: 0, Rt2: 1, Imm: 8, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, LdrdImm32T1)
+}
+
+func TestDecodeMemoryHint32(t *testing.T) {
+    cases := []DecodeCase{
+        // pld [r1, #4]
+        {instr: FetchedInstr32(0xf891f004), decoded: PldImmT1{Rn: 1, Imm: 4, setflags: NEVER}},
+        // pld [r1, #-4]
+        {instr: FetchedInstr32(0xf811fc04), decoded: PldImmT2{Rn: 1, Imm: 0xfffffffc, setflags: NEVER}},
+        // pld [pc, #-8]
+        {instr: FetchedInstr32(0xf81ff008), decoded: PldLitT1{Imm: 0xfffffff8, setflags: NEVER}},
+        // pld [r1, r2, lsl #2]
+        {instr: FetchedInstr32(0xf811f022), decoded: PldRegT1{Rn: 1, Rm: 2, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER}},
+        // pli [r1, #4]
+        {instr: FetchedInstr32(0xf991f004), decoded: PliImmT1{Rn: 1, Imm: 4, setflags: NEVER}},
+        // pli [r1, #-4]
+        {instr: FetchedInstr32(0xf911fc04), decoded: PliImmT2{Rn: 1, Imm: 0xfffffffc, setflags: NEVER}},
+        // pli [pc, #8]
+        {instr: FetchedInstr32(0xf99ff008), decoded: PliLitT3{Imm: 8, setflags: NEVER}},
+        // pli [r1, r2]
+        {instr: FetchedInstr32(0xf911f002), decoded: PliRegT1{Rn: 1, Rm: 2, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, MemoryHint32)
+}
+
+func TestExecuteMemoryHint(t *testing.T) {
+    cases := []ExecuteCase{
+        // pld [r1, #4]
+        {instr: PldImmT1{Rn: 1, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 4}},
+            expected:     Registers{r: GeneralRegs{0, 4}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0, 0x11, 0x22}},
+        // pld [r1, #-4], beyond the end of memory
+        {instr: PldImmT2{Rn: 1, Imm: 0xfffffffc, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0x1000}},
+            expected:     Registers{r: GeneralRegs{0, 0x1000}},
+            mem:          Block{0x11, 0x22},
+            expected_mem: Block{0x11, 0x22}},
+        // pld [pc, #8]
+        {instr: PldLitT1{Imm: 8, setflags: NEVER},
+            regs:         Registers{pc: 0x4},
+            expected:     Registers{pc: 0x4},
+            mem:          Block{0x11, 0x22},
+            expected_mem: Block{0x11, 0x22}},
+        // pld [r1, r2, lsl #2]
+        {instr: PldRegT1{Rn: 1, Rm: 2, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0, 1}},
+            expected:     Registers{r: GeneralRegs{0, 0, 1}},
+            mem:          Block{0, 0, 0, 0, 0x11, 0x22},
+            expected_mem: Block{0, 0, 0, 0, 0x11, 0x22}},
+        // pli [r1, #4]
+        {instr: PliImmT1{Rn: 1, Imm: 4, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0}},
+            expected:     Registers{r: GeneralRegs{0, 0}},
+            mem:          Block{0, 0, 0, 0, 0x11, 0x22},
+            expected_mem: Block{0, 0, 0, 0, 0x11, 0x22}},
+        // pli [r1, #-4]
+        {instr: PliImmT2{Rn: 1, Imm: 0xfffffffc, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 4}},
+            expected:     Registers{r: GeneralRegs{0, 4}},
+            mem:          Block{0x11, 0x22},
+            expected_mem: Block{0x11, 0x22}},
+        // pli [pc, #-4]
+        {instr: PliLitT3{Imm: 0xfffffffc, setflags: NEVER},
+            regs:         Registers{pc: 0x8},
+            expected:     Registers{pc: 0x8},
+            mem:          Block{0x11, 0x22},
+            expected_mem: Block{0x11, 0x22}},
+        // pli [r1, r2]
+        {instr: PliRegT1{Rn: 1, Rm: 2, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0, 1}},
+            expected:     Registers{r: GeneralRegs{0, 0, 1}},
+            mem:          Block{0x11, 0x22},
+            expected_mem: Block{0x11, 0x22}},
+        // ldrh.w pc, [r1, #4] (unallocated hint)
+        {instr: UnallocatedHint{},
+            regs:         Registers{r: GeneralRegs{0, 0}, pc: 0x4},
+            expected:     Registers{r: GeneralRegs{0, 0}, pc: 0x4},
+            mem:          Block{0, 0, 0, 0, 0x11, 0x22},
+            expected_mem: Block{0, 0, 0, 0, 0x11, 0x22}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteLdrImmT4(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr r0, [r1, #-4]
+        {instr: LdrImmT4{Rt: 0, Rn: 1, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 8}},
+            expected: Registers{r: GeneralRegs{0x44332211, 8}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr r0, [r1, #4]!
+        {instr: LdrImmT4{Rt: 0, Rn: 1, Imm: 4, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0x44332211, 4}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr r0, [r1], #-4
+        {instr: LdrImmT4{Rt: 0, Rn: 1, Imm: 0xfffffffc, PostIndex: true, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 4}},
+            expected: Registers{r: GeneralRegs{0x44332211, 0}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr r0, [r1, #4]!, outside of memory
+        {instr: LdrImmT4{Rt: 0, Rn: 1, Imm: 4, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0xdead, 4}},
+            expected: Registers{r: GeneralRegs{0xdead, 4}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0},
+            err:      BusError{Addr: 8, Size: 4, Write: false}},
+        // ldr r1, [r1, #4]! (UNPREDICTABLE)
+        {instr: LdrImmT4{Rt: 1, Rn: 1, Imm: 4, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr pc, [sp], #4
+        {instr: LdrImmT4{Rt: PC, Rn: SP, Imm: 4, PostIndex: true, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{4, 0}, pc: 0x4, Epsr: Epsr{T: true}},
+            expected: Registers{sp: SPRegs{8, 0}, pc: 0x100, branched: true, Epsr: Epsr{T: true}},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x01, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteLoadStore32(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldr.w r0, [r1, r2, lsl #2]
+        {instr: LdrRegT2{Rt: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 2, 1}},
+            expected: Registers{r: GeneralRegs{0x44332211, 2, 1}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldr.w r0, [r1, sp] (UNPREDICTABLE)
+        {instr: LdrRegT2{Rt: 0, Rm: SP, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0}},
+            mem:      Block{0x11, 0x22, 0x33, 0x44}},
+        // ldrsb r0, [r1], #4
+        {instr: LdrsbImmT2{Rt: 0, Rn: 1, Imm: 4, PostIndex: true, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1}},
+            expected: Registers{r: GeneralRegs{0xffffff80, 5}},
+            mem:      Block{0, 0x80}},
+        // ldrsh r0, [r1, #-2]!
+        {instr: LdrshImmT2{Rt: 0, Rn: 1, Imm: 0xfffffffe, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 4}},
+            expected: Registers{r: GeneralRegs{0xffff8001, 2}},
+            mem:      Block{0, 0, 0x01, 0x80}},
+        // ldrh.w r0, [r1, #2]
+        {instr: LdrhImmT2{Rt: 0, Rn: 1, Imm: 2, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0x8001, 0}},
+            mem:      Block{0, 0, 0x01, 0x80}},
+        // ldrb.w sp, [r1, #0] (UNPREDICTABLE)
+        {instr: LdrbImmT2{Rt: SP, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0}},
+            mem:      Block{0x80}},
+        // ldrsb.w r0, [pc, #-4]
+        {instr: LdrsbLitT1{Rt: 0, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x6},
+            expected: Registers{r: GeneralRegs{0xfffffff0}, pc: 0x6},
+            mem:      Block{0xf0, 0, 0, 0}},
+        // strb r0, [r1, #-4]
+        {instr: StrbImmT3{Rt: 0, Rn: 1, Imm: 0xfffffffc, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x1234, 5}},
+            expected:     Registers{r: GeneralRegs{0x1234, 5}},
+            mem:          Block{0, 0, 0},
+            expected_mem: Block{0, 0x34, 0}},
+        // strh.w r0, [r1, r2, lsl #1]
+        {instr: StrhRegT2{Rt: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 1}, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x12345678, 0, 1}},
+            expected:     Registers{r: GeneralRegs{0x12345678, 0, 1}},
+            mem:          Block{0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0x78, 0x56}},
+        // str r0, [sp, #-4]!
+        {instr: StrImmT4{Rt: 0, Rn: SP, Imm: 0xfffffffc, Wback: true, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x44332211}, sp: SPRegs{8, 0}},
+            expected:     Registers{r: GeneralRegs{0x44332211}, sp: SPRegs{4, 0}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // str r0, [sp, #-4]!, outside of memory
+        {instr: StrImmT4{Rt: 0, Rn: SP, Imm: 0xfffffffc, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0, 0}},
+            expected: Registers{sp: SPRegs{0, 0}},
+            mem:      Block{0, 0, 0, 0},
+            err:      BusError{Addr: 0xfffffffc, Size: 4, Write: true}},
+        // str.w pc, [r1, #0] (UNPREDICTABLE)
+        {instr: StrImmT3{Rt: PC, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:         Registers{pc: 0x100},
+            expected:     Registers{pc: 0x100},
+            mem:          Block{0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteLoadStoreDual(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldrd r0, r1, [r2, #-8]!
+        {instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 0xfffffff8, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 8}},
+            expected: Registers{r: GeneralRegs{0x44332211, 0x88776655, 0}},
+            mem:      Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldrd r0, r1, [r2, #2], unaligned
+        {instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 2, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+            err:      UFSR_UNALIGNED},
+        // ldrd r0, r1, [r2], partly outside of memory
+        {instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, PostIndex: true, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0xdead, 0xbeef, 0}},
+            expected: Registers{r: GeneralRegs{0xdead, 0xbeef, 0}},
+            mem:      Block{0, 0, 0, 0},
+            err:      BusError{Addr: 4, Size: 4, Write: false}},
+        // ldrd r0, r0, [r2] (UNPREDICTABLE)
+        {instr: LdrdImmT1{Rt: 0, Rt2: 0, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0}},
+            mem:      Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldrd r0, r1, [r1, #0]! (UNPREDICTABLE)
+        {instr: LdrdImmT1{Rt: 0, Rt2: 1, Rn: 1, Imm: 0, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0}},
+            mem:      Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldrd r0, r1, [pc, #-4]
+        {instr: LdrdLitT1{Rt: 0, Rt2: 1, Imm: 0xfffffffc, setflags: NEVER},
+            regs:     Registers{pc: 0x6},
+            expected: Registers{r: GeneralRegs{0x44332211, 0x88776655}, pc: 0x6},
+            mem:      Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // strd r0, r1, [r2], #8
+        {instr: StrdImmT1{Rt: 0, Rt2: 1, Rn: 2, Imm: 8, PostIndex: true, Wback: true, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x44332211, 0x88776655, 0}},
+            expected:     Registers{r: GeneralRegs{0x44332211, 0x88776655, 8}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // strd r0, sp, [r2] (UNPREDICTABLE)
+        {instr: StrdImmT1{Rt: 0, Rt2: SP, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0x44332211, 0, 0}},
+            expected:     Registers{r: GeneralRegs{0x44332211, 0, 0}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringLoadStore32(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xf8d10004, expected: "ldr.w r0, [r1, #4]"},
+        {instr: 0xf8510c04, expected: "ldr r0, [r1, #-4]"},
+        {instr: 0xf8510f04, expected: "ldr r0, [r1, #4]!"},
+        {instr: 0xf8510904, expected: "ldr r0, [r1], #-4"},
+        {instr: 0xf8510002, expected: "ldr.w r0, [r1, r2]"},
+        {instr: 0xf8510022, expected: "ldr.w r0, [r1, r2, lsl #2]"},
+        {instr: 0xf8210012, expected: "strh.w r0, [r1, r2, lsl #1]"},
+        {instr: 0xf9910004, expected: "ldrsb.w r0, [r1, #4]"},
+        {instr: 0xf9310d02, expected: "ldrsh r0, [r1, #-2]!"},
+        {instr: 0xe9720102, expected: "ldrd r0, r1, [r2, #-8]!"},
+        {instr: 0xe8e20102, expected: "strd r0, r1, [r2], #8"},
+        {instr: 0xf891f004, expected: "pld [r1, #4]"},
+        {instr: 0xf811fc04, expected: "pld [r1, #-4]"},
+        {instr: 0xf811f022, expected: "pld [r1, r2, lsl #2]"},
+        {instr: 0xf991f004, expected: "pli [r1, #4]"},
+        {instr: 0xf911fc04, expected: "pli [r1, #-4]"},
+        {instr: 0xf911f002, expected: "pli [r1, r2]"},
+    }
+
+    for _, test := range cases {
+        instr, err := test.instr.Decode()
+        if err != nil {
+            t.Errorf("%#x: %v", uint32(test.instr), err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", uint32(test.instr), actual, test.expected)
+        }
+    }
+}
+
+func TestIdentifyLoadStoreMultiple32(t *testing.T) {
+    cases := []struct {
+        instr      FetchedInstr32
+        instr_type reflect.Type
+    }{
+        {instr: 0xe8b00206, instr_type: reflect.TypeOf(LdmT2{})},    // ldm.w r0!, {r1, r2, r9}
+        {instr: 0xe8900206, instr_type: reflect.TypeOf(LdmT2{})},    // ldm.w r0, {r1, r2, r9}
+        {instr: 0xe8a00206, instr_type: reflect.TypeOf(StmT2{})},    // stm.w r0!, {r1, r2, r9}
+        {instr: 0xe9200206, instr_type: reflect.TypeOf(StmdbT1{})},  // stmdb r0!, {r1, r2, r9}
+        {instr: 0xe9108006, instr_type: reflect.TypeOf(LdmdbT1{})},  // ldmdb r0, {r1, r2, pc}
+        {instr: 0xe92d41f0, instr_type: reflect.TypeOf(PushT2{})},   // push.w {r4, r5, r6, r7, r8, lr}
+        {instr: 0xe8bd81f0, instr_type: reflect.TypeOf(PopT2{})},    // pop.w {r4, r5, r6, r7, r8, pc}
+        {instr: 0xe89d0110, instr_type: reflect.TypeOf(LdmT2{})},    // ldm.w sp, {r4, r8}
+        {instr: 0xf84d8d04, instr_type: reflect.TypeOf(StrImmT4{})}, // str r8, [sp, #-4]!
+        {instr: 0xf85d8b04, instr_type: reflect.TypeOf(LdrImmT4{})}, // ldr r8, [sp], #4
+    }
+
+    for _, test := range cases {
+        instr, _ := test.instr.Decode()
+        if reflect.TypeOf(instr) != test.instr_type {
+            t.Errorf("%#x: %T, expected %v", uint32(test.instr), instr, test.instr_type)
+        }
+    }
+}
+
+func TestDecodeLdm32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // ldm.w r0!, {r1, r2, r9}
+        {instr: FetchedInstr32(0xe8b00206), decoded: LdmT2{Rn: 0, RegList: 0x0206, Wback: true, setflags: NEVER}},
+        // ldm.w r0, {r1, r2, r9}
+        {instr: FetchedInstr32(0xe8900206), decoded: LdmT2{Rn: 0, RegList: 0x0206, Wback: false, setflags: NEVER}},
+        // pop.w {r4, r5, r6, r7, r8, pc}
+        {instr: FetchedInstr32(0xe8bd81f0), decoded: PopT2{Rn: SP, RegList: 0x81f0, Wback: true, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Ldm32T2)
+}
+
+func TestDecodeStmdb32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // stmdb r0!, {r1, r2, r9}
+        {instr: FetchedInstr32(0xe9200206), decoded: StmdbT1{Rn: 0, RegList: 0x0206, Wback: true, setflags: NEVER}},
+        // push.w {r4, r5, r6, r7, r8, lr}
+        {instr: FetchedInstr32(0xe92d41f0), decoded: PushT2{Rn: SP, RegList: 0x41f0, Wback: true, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Stmdb32T1)
+}
+
+func TestExecuteLoadMultiple32(t *testing.T) {
+    cases := []ExecuteCase{
+        // ldm.w r0!, {r1, r9}
+        {instr: LdmT2{Rn: 0, RegList: 0x0202, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4}},
+            expected: Registers{r: GeneralRegs{12, 0x44332211, 9: 0x88776655}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldm.w r0, {r0, r9}
+        {instr: LdmT2{Rn: 0, RegList: 0x0201, Wback: false, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4}},
+            expected: Registers{r: GeneralRegs{0x44332211, 9: 0x88776655}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldm.w r0!, {r0, r9} (UNPREDICTABLE)
+        {instr: LdmT2{Rn: 0, RegList: 0x0201, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4}},
+            expected: Registers{r: GeneralRegs{4}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // ldm.w r0, {r1} (UNPREDICTABLE)
+        {instr: LdmT2{Rn: 0, RegList: 0x0002, Wback: false, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{4}},
+            expected: Registers{r: GeneralRegs{4}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44}},
+        // ldmdb r0!, {r1, pc}
+        {instr: LdmdbT1{Rn: 0, RegList: 0x8002, Wback: true, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{12}, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{4, 0x44332211}, pc: 0x100, branched: true, Epsr: Epsr{T: true}},
+            mem:      Block{0, 0, 0, 0, 0x11, 0x22, 0x33, 0x44, 0x01, 0x01, 0, 0}},
+        // ldmdb r0, {r1, r2}, partly outside of memory
+        {instr: LdmdbT1{Rn: 0, RegList: 0x0006, Wback: false, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{8, 0xdead, 0xbeef}},
+            expected: Registers{r: GeneralRegs{8, 0xdead, 0xbeef}},
+            mem:      Block{0, 0, 0, 0},
+            err:      BusError{Addr: 4, Size: 4, Write: false}},
+        // pop.w {r4, r8, pc}
+        {instr: PopT2{Rn: SP, RegList: 0x8110, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0, 0}, Epsr: Epsr{T: true}},
+            expected: Registers{r: GeneralRegs{4: 0x44332211, 8: 0x88776655}, sp: SPRegs{12, 0}, pc: 0x200, branched: true, Epsr: Epsr{T: true}},
+            mem:      Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x01, 0x02, 0, 0}},
+        // pop.w {r4, lr, pc} (UNPREDICTABLE)
+        {instr: PopT2{Rn: SP, RegList: 0xc010, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0, 0}},
+            expected: Registers{sp: SPRegs{0, 0}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+        // pop.w {r4, pc}, not last in an IT block (UNPREDICTABLE)
+        {instr: PopT2{Rn: SP, RegList: 0x8010, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{0, 0}, Epsr: Epsr{T: true, IT: 0x04}},
+            expected: Registers{sp: SPRegs{0, 0}, Epsr: Epsr{T: true, IT: 0x04}},
+            mem:      Block{0, 0, 0, 0, 0x01, 0x01, 0, 0}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteStoreMultiple32(t *testing.T) {
+    cases := []ExecuteCase{
+        // stm.w r0!, {r1, r9}
+        {instr: StmT2{Rn: 0, RegList: 0x0202, Wback: true, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 0x44332211, 9: 0x88776655}},
+            expected:     Registers{r: GeneralRegs{8, 0x44332211, 9: 0x88776655}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // stmdb r0, {r1, r9}
+        {instr: StmdbT1{Rn: 0, RegList: 0x0202, Wback: false, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{8, 0x44332211, 9: 0x88776655}},
+            expected:     Registers{r: GeneralRegs{8, 0x44332211, 9: 0x88776655}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}},
+        // stm.w r0, {r1, pc} (UNPREDICTABLE)
+        {instr: StmT2{Rn: 0, RegList: 0x8002, Wback: false, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{0, 1}},
+            expected:     Registers{r: GeneralRegs{0, 1}},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0, 0, 0, 0, 0, 0, 0, 0}},
+        // push.w {r4, r8, lr}
+        {instr: PushT2{Rn: SP, RegList: 0x4110, Wback: true, setflags: NEVER},
+            regs:         Registers{r: GeneralRegs{4: 0x44332211, 8: 0x88776655}, sp: SPRegs{12, 0}, lr: 0x201},
+            expected:     Registers{r: GeneralRegs{4: 0x44332211, 8: 0x88776655}, sp: SPRegs{0, 0}, lr: 0x201},
+            mem:          Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+            expected_mem: Block{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x01, 0x02, 0, 0}},
+        // push.w {r4, r8}, unaligned
+        {instr: PushT2{Rn: SP, RegList: 0x0110, Wback: true, setflags: NEVER},
+            regs:     Registers{sp: SPRegs{10, 0}},
+            expected: Registers{sp: SPRegs{10, 0}},
+            mem:      Block{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+            err:      UFSR_UNALIGNED},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringLoadStoreMultiple32(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xe8b00206, expected: "ldm.w r0!, {r1, r2, r9}"},
+        {instr: 0xe8900206, expected: "ldm.w r0, {r1, r2, r9}"},
+        {instr: 0xe8a00206, expected: "stm.w r0!, {r1, r2, r9}"},
+        {instr: 0xe9200206, expected: "stmdb r0!, {r1, r2, r9}"},
+        {instr: 0xe9108006, expected: "ldmdb r0, {r1, r2, pc}"},
+        {instr: 0xe92d41f0, expected: "push.w {r4, r5, r6, r7, r8, lr}"},
+        {instr: 0xe8bd81f0, expected: "pop.w {r4, r5, r6, r7, r8, pc}"},
+    }
+
+    for _, test := range cases {
+        instr, err := test.instr.Decode()
+        if err != nil {
+            t.Errorf("%#x: %v", uint32(test.instr), err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", uint32(test.instr), actual, test.expected)
+        }
+    }
+}
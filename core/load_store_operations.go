@@ -0,0 +1,242 @@
+package core
+
+import (
+    "bytes"
+    "fmt"
+    "math/bits"
+)
+
+/* Set of registers, one bit for each of R0 to R15 */
+type RegisterList uint16
+
+func (list RegisterList) Count() uint32 {
+    return uint32(bits.OnesCount16(uint16(list)))
+}
+
+func (list RegisterList) Contains(i RegIndex) bool {
+    return (list>>i)&0x1 != 0
+}
+
+func (list RegisterList) String() string {
+    var b bytes.Buffer
+    var i RegIndex
+
+    fmt.Fprintf(&b, "{")
+    for i = 0; i <= PC; i++ {
+        if list.Contains(i) {
+            if b.Len() > 1 {
+                fmt.Fprintf(&b, ", ")
+            }
+            fmt.Fprintf(&b, "%s", i)
+        }
+    }
+    fmt.Fprintf(&b, "}")
+
+    return b.String()
+}
+
+/* Read size bytes from memory, zero extended */
+func MemRead(mem Memory, addr uint32, size uint32) (uint32, error) {
+    switch size {
+    case 1:
+        value, err := mem.Read8(addr)
+        return uint32(value), err
+    case 2:
+        value, err := mem.Read16(addr)
+        return uint32(value), err
+    default:
+        return mem.Read32(addr)
+    }
+}
+
+/* Write the low size bytes of value to memory */
+func MemWrite(mem Memory, addr uint32, size uint32, value uint32) error {
+    switch size {
+    case 1:
+        return mem.Write8(addr, uint8(value))
+    case 2:
+        return mem.Write16(addr, uint16(value))
+    default:
+        return mem.Write32(addr, value)
+    }
+}
+
+/* Sign extend the low size bytes of value */
+func SignExtend(value uint32, size uint32) uint32 {
+    shift := 32 - 8*size
+    return uint32(int32(value<<shift) >> shift)
+}
+
+/* Load Rt from addr, sign extending if signed.  Rt is left unchanged if
+ * the access fails. */
+func load(regs *Registers, mem Memory, rt RegIndex, addr uint32, size uint32, signed bool) error {
+    value, err := MemRead(mem, addr, size)
+    if err != nil {
+        return err
+    }
+
+    if signed {
+        value = SignExtend(value, size)
+    }
+
+    if rt == PC {
+        regs.LoadWritePC(value)
+    } else {
+        regs.SetR(rt, value)
+    }
+
+    return nil
+}
+
+/* Address to access for a load or store with an immediate offset, and the
+ * value Rn is written back with */
+func indexed_address(regs *Registers, instr InstrFields) (addr uint32, offset_addr uint32) {
+    offset_addr = regs.R(instr.Rn) + instr.Imm
+
+    if instr.PostIndex {
+        return regs.R(instr.Rn), offset_addr
+    }
+
+    return offset_addr, offset_addr
+}
+
+/* Perform load instruction (imm), from Rn plus offset, writing back Rn
+ * plus offset if wback.  Rn is left unchanged if the access fails. */
+func LoadImmediate(regs *Registers, mem Memory, instr InstrFields, size uint32, signed bool) error {
+    addr, offset_addr := indexed_address(regs, instr)
+
+    if err := load(regs, mem, instr.Rt, addr, size, signed); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, offset_addr)
+    }
+
+    return nil
+}
+
+/* Perform store instruction (imm), to Rn plus offset, writing back Rn
+ * plus offset if wback */
+func StoreImmediate(regs *Registers, mem Memory, instr InstrFields, size uint32) error {
+    addr, offset_addr := indexed_address(regs, instr)
+
+    if err := MemWrite(mem, addr, size, regs.R(instr.Rt)); err != nil {
+        return err
+    }
+
+    if instr.Wback {
+        regs.SetR(instr.Rn, offset_addr)
+    }
+
+    return nil
+}
+
+/* Perform load instruction (reg), from Rn plus shifted Rm */
+func LoadRegister(regs *Registers, mem Memory, instr InstrFields, shift Shift, size uint32, signed bool) error {
+    offset, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    return load(regs, mem, instr.Rt, regs.R(instr.Rn)+offset, size, signed)
+}
+
+/* Perform store instruction (reg), to Rn plus shifted Rm */
+func StoreRegister(regs *Registers, mem Memory, instr InstrFields, shift Shift, size uint32) error {
+    offset, _ := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    return MemWrite(mem, regs.R(instr.Rn)+offset, size, regs.R(instr.Rt))
+}
+
+/* Perform load instruction (literal), from the word-aligned PC plus offset */
+func LoadLiteral(regs *Registers, mem Memory, instr InstrFields, size uint32, signed bool) error {
+    return load(regs, mem, instr.Rt, align(regs.Pc(), 4)+instr.Imm, size, signed)
+}
+
+/* Load Rt and Rt2 from consecutive words at the word-aligned addr.
+ * Neither register is changed if either access fails. */
+func LoadDual(regs *Registers, mem Memory, instr InstrFields, addr uint32) error {
+    if addr&0x3 != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    value, err := mem.Read32(addr)
+    if err != nil {
+        return err
+    }
+
+    value2, err := mem.Read32(addr + 4)
+    if err != nil {
+        return err
+    }
+
+    regs.SetR(instr.Rt, value)
+    regs.SetR(instr.Rt2, value2)
+
+    return nil
+}
+
+/* Store Rt and Rt2 to consecutive words at the word-aligned addr */
+func StoreDual(regs *Registers, mem Memory, instr InstrFields, addr uint32) error {
+    if addr&0x3 != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    if err := mem.Write32(addr, regs.R(instr.Rt)); err != nil {
+        return err
+    }
+
+    return mem.Write32(addr+4, regs.R(instr.Rt2))
+}
+
+/* Load the registers in list from consecutive words, lowest numbered
+ * register first, starting at the word-aligned addr.  No register is
+ * changed if any of the accesses fail. */
+func LoadMultiple(regs *Registers, mem Memory, list RegisterList, addr uint32) error {
+    var values [16]uint32
+    var i RegIndex
+
+    if addr&0x3 != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    for i = 0; i <= PC; i++ {
+        if list.Contains(i) {
+            value, err := mem.Read32(addr)
+            if err != nil {
+                return err
+            }
+            values[i] = value
+            addr += 4
+        }
+    }
+
+    for i = 0; i < PC; i++ {
+        if list.Contains(i) {
+            regs.SetR(i, values[i])
+        }
+    }
+
+    if list.Contains(PC) {
+        regs.LoadWritePC(values[PC])
+    }
+
+    return nil
+}
+
+/* Store the registers in list to consecutive words, lowest numbered
+ * register first, starting at the word-aligned addr */
+func StoreMultiple(regs *Registers, mem Memory, list RegisterList, addr uint32) error {
+    var i RegIndex
+
+    if addr&0x3 != 0 {
+        return UFSR_UNALIGNED
+    }
+
+    for i = 0; i <= PC; i++ {
+        if list.Contains(i) {
+            if err := mem.Write32(addr, regs.R(i)); err != nil {
+                return err
+            }
+            addr += 4
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,597 @@
+package core
+
+import "fmt"
+
+/* AND - Bitwise AND (register)
+ * ARM ARM A7.7.9
+ * Encoding T1 */
+type AndRegT1 InstrFields
+
+func AndReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return AndRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AndRegT1) Execute(regs *Registers, mem Memory) error {
+    BitwiseRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_and)
+    return nil
+}
+
+func (instr AndRegT1) String() string {
+    return fmt.Sprintf("and%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* EOR - Bitwise Exclusive OR (register)
+ * ARM ARM A7.7.35
+ * Encoding T1 */
+type EorRegT1 InstrFields
+
+func EorReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return EorRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr EorRegT1) Execute(regs *Registers, mem Memory) error {
+    BitwiseRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_eor)
+    return nil
+}
+
+func (instr EorRegT1) String() string {
+    return fmt.Sprintf("eor%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* ORR - Bitwise OR (register)
+ * ARM ARM A7.7.91
+ * Encoding T1 */
+type OrrRegT1 InstrFields
+
+func OrrReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return OrrRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr OrrRegT1) Execute(regs *Registers, mem Memory) error {
+    BitwiseRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_orr)
+    return nil
+}
+
+func (instr OrrRegT1) String() string {
+    return fmt.Sprintf("orr%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* BIC - Bitwise Bit Clear (register)
+ * ARM ARM A7.7.16
+ * Encoding T1 */
+type BicRegT1 InstrFields
+
+func BicReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return BicRegT1{Rd: Rdn, Rm: Rm, Rn: Rdn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr BicRegT1) Execute(regs *Registers, mem Memory) error {
+    BitwiseRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_bic)
+    return nil
+}
+
+func (instr BicRegT1) String() string {
+    return fmt.Sprintf("bic%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* MVN - Bitwise NOT (register)
+ * ARM ARM A7.7.85
+ * Encoding T1 */
+type MvnRegT1 InstrFields
+
+func MvnReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return MvnRegT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr MvnRegT1) Execute(regs *Registers, mem Memory) error {
+    BitwiseRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_mvn)
+    return nil
+}
+
+func (instr MvnRegT1) String() string {
+    return fmt.Sprintf("mvn%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* TST - Test (register)
+ * ARM ARM A7.7.186
+ * Encoding T1 */
+type TstRegT1 InstrFields
+
+func TstReg16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return TstRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, setflags: ALWAYS}
+}
+
+func (instr TstRegT1) Execute(regs *Registers, mem Memory) error {
+    TestRegister(regs, InstrFields(instr), Shift{srtype: SRTYPE_LSL, amount: 0}, bitwise_and)
+    return nil
+}
+
+func (instr TstRegT1) String() string {
+    return fmt.Sprintf("tst %s, %s", instr.Rn, instr.Rm)
+}
+
+/* AND - Bitwise AND (immediate)
+ * ARM ARM A7.7.8
+ * Encoding T1 */
+type AndImmT1 InstrFields
+
+func AndImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return TstImm32T1(instr)
+    }
+
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return AndImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr AndImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_and)
+
+    return nil
+}
+
+func (instr AndImmT1) String() string {
+    return fmt.Sprintf("and%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* TST - Test (immediate)
+ * ARM ARM A7.7.185
+ * Encoding T1 */
+type TstImmT1 InstrFields
+
+func TstImm32T1(instr FetchedInstr) DecodedInstr {
+    _, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return TstImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: ALWAYS}
+}
+
+func (instr TstImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    TestImmediate(regs, InstrFields(instr), bitwise_and)
+
+    return nil
+}
+
+func (instr TstImmT1) String() string {
+    return fmt.Sprintf("tst.w %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* BIC - Bitwise Bit Clear (immediate)
+ * ARM ARM A7.7.15
+ * Encoding T1 */
+type BicImmT1 InstrFields
+
+func BicImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return BicImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr BicImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_bic)
+
+    return nil
+}
+
+func (instr BicImmT1) String() string {
+    return fmt.Sprintf("bic%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ORR - Bitwise OR (immediate)
+ * ARM ARM A7.7.90
+ * Encoding T1 */
+type OrrImmT1 InstrFields
+
+func OrrImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rn == PC {
+        return MovImm32T2(instr)
+    }
+
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return OrrImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr OrrImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_orr)
+
+    return nil
+}
+
+func (instr OrrImmT1) String() string {
+    return fmt.Sprintf("orr%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* ORN - Bitwise OR NOT (immediate)
+ * ARM ARM A7.7.88
+ * Encoding T1 */
+type OrnImmT1 InstrFields
+
+func OrnImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rn == PC {
+        return MvnImm32T1(instr)
+    }
+
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return OrnImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr OrnImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_orn)
+
+    return nil
+}
+
+func (instr OrnImmT1) String() string {
+    return fmt.Sprintf("orn%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* MVN - Bitwise NOT (immediate)
+ * ARM ARM A7.7.84
+ * Encoding T1 */
+type MvnImmT1 InstrFields
+
+func MvnImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, setflags := mod_imm_fields(instr.Uint32())
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return MvnImmT1{Rd: Rd, Rm: 0, Rn: 0, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr MvnImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_mvn)
+
+    return nil
+}
+
+func (instr MvnImmT1) String() string {
+    return fmt.Sprintf("mvn%s %s, #%d", instr.setflags, instr.Rd, instr.Imm)
+}
+
+/* EOR - Bitwise Exclusive OR (immediate)
+ * ARM ARM A7.7.34
+ * Encoding T1 */
+type EorImmT1 InstrFields
+
+func EorImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, imm12, setflags := mod_imm_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return TeqImm32T1(instr)
+    }
+
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return EorImmT1{Rd: Rd, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr EorImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseImmediate(regs, InstrFields(instr), bitwise_eor)
+
+    return nil
+}
+
+func (instr EorImmT1) String() string {
+    return fmt.Sprintf("eor%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rn, instr.Imm)
+}
+
+/* TEQ - Test Equivalence (immediate)
+ * ARM ARM A7.7.183
+ * Encoding T1 */
+type TeqImmT1 InstrFields
+
+func TeqImm32T1(instr FetchedInstr) DecodedInstr {
+    _, Rn, imm12, _ := mod_imm_fields(instr.Uint32())
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return TeqImmT1{Rd: 0, Rm: 0, Rn: Rn, Imm: imm32, Carry: carry, setflags: ALWAYS}
+}
+
+func (instr TeqImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    TestImmediate(regs, InstrFields(instr), bitwise_eor)
+
+    return nil
+}
+
+func (instr TeqImmT1) String() string {
+    return fmt.Sprintf("teq %s, #%d", instr.Rn, instr.Imm)
+}
+
+/* AND - Bitwise AND (register)
+ * ARM ARM A7.7.9
+ * Encoding T2 */
+type AndRegT2 InstrFields
+
+func AndReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return TstReg32T2(instr)
+    }
+
+    return AndRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr AndRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_and)
+
+    return nil
+}
+
+func (instr AndRegT2) String() string {
+    return fmt.Sprintf("and%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* TST - Test (register)
+ * ARM ARM A7.7.186
+ * Encoding T2 */
+type TstRegT2 InstrFields
+
+func TstReg32T2(instr FetchedInstr) DecodedInstr {
+    _, Rn, Rm, shift, _ := shifted_reg_fields(instr.Uint32())
+
+    return TstRegT2{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: ALWAYS}
+}
+
+func (instr TstRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    TestRegister(regs, InstrFields(instr), instr.Shift, bitwise_and)
+
+    return nil
+}
+
+func (instr TstRegT2) String() string {
+    return fmt.Sprintf("tst.w %s, %s%s", instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* BIC - Bitwise Bit Clear (register)
+ * ARM ARM A7.7.16
+ * Encoding T2 */
+type BicRegT2 InstrFields
+
+func BicReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return BicRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr BicRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_bic)
+
+    return nil
+}
+
+func (instr BicRegT2) String() string {
+    return fmt.Sprintf("bic%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* ORR - Bitwise OR (register)
+ * ARM ARM A7.7.91
+ * Encoding T2 */
+type OrrRegT2 InstrFields
+
+func OrrReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rn == PC {
+        /* Move register and immediate shifts */
+        switch shift.srtype {
+        case SRTYPE_LSL:
+            return LslImm32T2(instr)
+        case SRTYPE_LSR:
+            return LsrImm32T2(instr)
+        case SRTYPE_ASR:
+            return AsrImm32T2(instr)
+        case SRTYPE_ROR:
+            return RorImm32T1(instr)
+        default:
+            return Rrx32T1(instr)
+        }
+    }
+
+    return OrrRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr OrrRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_orr)
+
+    return nil
+}
+
+func (instr OrrRegT2) String() string {
+    return fmt.Sprintf("orr%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* ORN - Bitwise OR NOT (register)
+ * ARM ARM A7.7.89
+ * Encoding T1 */
+type OrnRegT1 InstrFields
+
+func OrnReg32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rn == PC {
+        return MvnReg32T2(instr)
+    }
+
+    return OrnRegT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr OrnRegT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || instr.Rn == SP || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_orn)
+
+    return nil
+}
+
+func (instr OrnRegT1) String() string {
+    return fmt.Sprintf("orn%s %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* MVN - Bitwise NOT (register)
+ * ARM ARM A7.7.85
+ * Encoding T2 */
+type MvnRegT2 InstrFields
+
+func MvnReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return MvnRegT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr MvnRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_mvn)
+
+    return nil
+}
+
+func (instr MvnRegT2) String() string {
+    return fmt.Sprintf("mvn%s.w %s, %s%s", instr.setflags, instr.Rd, instr.Rm, instr.Shift)
+}
+
+/* EOR - Bitwise Exclusive OR (register)
+ * ARM ARM A7.7.35
+ * Encoding T2 */
+type EorRegT2 InstrFields
+
+func EorReg32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if Rd == PC && setflags == ALWAYS {
+        return TeqReg32T1(instr)
+    }
+
+    return EorRegT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: setflags}
+}
+
+func (instr EorRegT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    BitwiseRegister(regs, InstrFields(instr), instr.Shift, bitwise_eor)
+
+    return nil
+}
+
+func (instr EorRegT2) String() string {
+    return fmt.Sprintf("eor%s.w %s, %s, %s%s", instr.setflags, instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
+
+/* TEQ - Test Equivalence (register)
+ * ARM ARM A7.7.184
+ * Encoding T1 */
+type TeqRegT1 InstrFields
+
+func TeqReg32T1(instr FetchedInstr) DecodedInstr {
+    _, Rn, Rm, shift, _ := shifted_reg_fields(instr.Uint32())
+
+    return TeqRegT1{Rd: 0, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: ALWAYS}
+}
+
+func (instr TeqRegT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    TestRegister(regs, InstrFields(instr), instr.Shift, bitwise_eor)
+
+    return nil
+}
+
+func (instr TeqRegT1) String() string {
+    return fmt.Sprintf("teq %s, %s%s", instr.Rn, instr.Rm, instr.Shift)
+}
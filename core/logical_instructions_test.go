@@ -0,0 +1,626 @@
+package core
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyAndRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4008), instr_valid: true},  // ands r0, r1
+        {instr: FetchedInstr16(0x4077), instr_valid: false}, // eors r7, r6
+        {instr: FetchedInstr16(0x4223), instr_valid: false}, // tst r3, r4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AndRegT1{}))
+}
+
+func TestDecodeAndReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ands r0, r1
+        {instr: FetchedInstr16(0x4008), decoded: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, AndReg16T1)
+}
+
+func TestExecuteAndRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // ands r0, r1
+        {instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xff00ff00, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x0f000f00, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ands r0, r1, carry and overflow are unaffected
+        {instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xf0000000, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}},
+            expected: Registers{r: GeneralRegs{0, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true, V: true}}},
+        // ands r0, r1, in an IT block
+        {instr: AndRegT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xf0000000, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 0x0ff00ff0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyEorRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4077), instr_valid: true},  // eors r7, r6
+        {instr: FetchedInstr16(0x4008), instr_valid: false}, // ands r0, r1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(EorRegT1{}))
+}
+
+func TestDecodeEorReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // eors r7, r6
+        {instr: FetchedInstr16(0x4077), decoded: EorRegT1{Rd: 7, Rm: 6, Rn: 7, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, EorReg16T1)
+}
+
+func TestExecuteEorRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // eors r7, r6
+        {instr: EorRegT1{Rd: 7, Rm: 6, Rn: 7, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x0ff00ff0, 0xff00ff00, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0x0ff00ff0, 0xf0f0f0f0, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyOrrRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x431a), instr_valid: true},  // orrs r2, r3
+        {instr: FetchedInstr16(0x43ac), instr_valid: false}, // bics r4, r5
+    }
+
+    test_identify(t, cases, reflect.TypeOf(OrrRegT1{}))
+}
+
+func TestDecodeOrrReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // orrs r2, r3
+        {instr: FetchedInstr16(0x431a), decoded: OrrRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, OrrReg16T1)
+}
+
+func TestExecuteOrrRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // orrs r2, r3
+        {instr: OrrRegT1{Rd: 2, Rm: 3, Rn: 2, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 0xf0, 0x0f, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 0xff, 0x0f, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBicRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x43ac), instr_valid: true},  // bics r4, r5
+        {instr: FetchedInstr16(0x43d1), instr_valid: false}, // mvns r1, r2
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BicRegT1{}))
+}
+
+func TestDecodeBicReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // bics r4, r5
+        {instr: FetchedInstr16(0x43ac), decoded: BicRegT1{Rd: 4, Rm: 5, Rn: 4, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, BicReg16T1)
+}
+
+func TestExecuteBicRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // bics r4, r5
+        {instr: BicRegT1{Rd: 4, Rm: 5, Rn: 4, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 0x0000ffff, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffff0000, 0x0000ffff, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMvnRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x43d1), instr_valid: true},  // mvns r1, r2
+        {instr: FetchedInstr16(0x4348), instr_valid: false}, // muls r0, r1, r0
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MvnRegT1{}))
+}
+
+func TestDecodeMvnReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // mvns r1, r2
+        {instr: FetchedInstr16(0x43d1), decoded: MvnRegT1{Rd: 1, Rm: 2, Rn: 0, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, MvnReg16T1)
+}
+
+func TestExecuteMvnRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // mvns r1, r2
+        {instr: MvnRegT1{Rd: 1, Rm: 2, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+        // mvns r1, r2
+        {instr: MvnRegT1{Rd: 1, Rm: 2, Rn: 0, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xfffffffd, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyTstRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4223), instr_valid: true},  // tst r3, r4
+        {instr: FetchedInstr16(0x4008), instr_valid: false}, // ands r0, r1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(TstRegT1{}))
+}
+
+func TestDecodeTstReg16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // tst r3, r4
+        {instr: FetchedInstr16(0x4223), decoded: TstRegT1{Rd: 0, Rm: 4, Rn: 3, Imm: 0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, TstReg16T1)
+}
+
+func TestExecuteTstRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // tst r3, r4
+        {instr: TstRegT1{Rd: 0, Rm: 4, Rn: 3, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xf0, 0x0f, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xf0, 0x0f, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // tst r3, r4, in an IT block
+        {instr: TstRegT1{Rd: 0, Rm: 4, Rn: 3, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 0x80000000, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x80000000, 0x80000000, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAndImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf00100ff), instr_valid: true},  // and r0, r1, #0xff
+        {instr: FetchedInstr32(0xf01312ab), instr_valid: true},  // ands r2, r3, #0xab00ab
+        {instr: FetchedInstr32(0xf0144f00), instr_valid: false}, // tst.w r4, #0x80000000
+        {instr: FetchedInstr32(0xf02625ab), instr_valid: false}, // bic r5, r6, #0xab00ab00
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AndImmT1{}))
+}
+
+func TestDecodeAndImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // and r0, r1, #0xff
+        {instr: FetchedInstr32(0xf00100ff), decoded: AndImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0xff, setflags: NEVER}},
+        // ands r2, r3, #0xab00ab
+        {instr: FetchedInstr32(0xf01312ab), decoded: AndImmT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0xab00ab, setflags: ALWAYS}},
+        // tst.w r4, #0x80000000
+        {instr: FetchedInstr32(0xf0144f00), decoded: TstImmT1{Rd: 0, Rm: 0, Rn: 4, Imm: 0x80000000, Carry: CARRY_SET, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, AndImm32T1)
+}
+
+func TestExecuteAndImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // and r0, r1, #0xff
+        {instr: AndImmT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0xff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x78, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ands r2, r3, #0xab00ab, an unrotated constant leaves carry alone
+        {instr: AndImmT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0xab00ab, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xff00ff00, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 0, 0xff00ff00, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true, V: true}}},
+        // ands r2, r3, #0x80000000, a rotated constant carries out its top bit
+        {instr: AndImmT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0x80000000, Carry: CARRY_SET, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xffffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x80000000, 0xffffffff, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+        // and sp, r1, #0xff (UNPREDICTABLE)
+        {instr: AndImmT1{Rd: SP, Rm: 0, Rn: 1, Imm: 0xff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyTstImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf0144f00), instr_valid: true},  // tst.w r4, #0x80000000
+        {instr: FetchedInstr32(0xf00100ff), instr_valid: false}, // and r0, r1, #0xff
+    }
+
+    test_identify(t, cases, reflect.TypeOf(TstImmT1{}))
+}
+
+func TestExecuteTstImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // tst.w r4, #0x80000000
+        {instr: TstImmT1{Rd: 0, Rm: 0, Rn: 4, Imm: 0x80000000, Carry: CARRY_SET, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x7fffffff, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x7fffffff, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // tst.w sp, #0x80000000 (UNPREDICTABLE)
+        {instr: TstImmT1{Rd: 0, Rm: 0, Rn: SP, Imm: 0x80000000, Carry: CARRY_SET, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyBicImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf02625ab), instr_valid: true},  // bic r5, r6, #0xab00ab00
+        {instr: FetchedInstr32(0xf00100ff), instr_valid: false}, // and r0, r1, #0xff
+    }
+
+    test_identify(t, cases, reflect.TypeOf(BicImmT1{}))
+}
+
+func TestDecodeBicImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // bic r5, r6, #0xab00ab00
+        {instr: FetchedInstr32(0xf02625ab), decoded: BicImmT1{Rd: 5, Rm: 0, Rn: 6, Imm: 0xab00ab00, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, BicImm32T1)
+}
+
+func TestExecuteBicImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // bic r5, r6, #0xab00ab00
+        {instr: BicImmT1{Rd: 5, Rm: 0, Rn: 6, Imm: 0xab00ab00, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0xffffffff, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x54ff54ff, 0xffffffff, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyOrrImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf04837ff), instr_valid: true},  // orr r7, r8, #0xffffffff
+        {instr: FetchedInstr32(0xf44f7b80), instr_valid: false}, // mov.w r11, #0x100
+    }
+
+    test_identify(t, cases, reflect.TypeOf(OrrImmT1{}))
+}
+
+func TestDecodeOrrImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // orr r7, r8, #0xffffffff
+        {instr: FetchedInstr32(0xf04837ff), decoded: OrrImmT1{Rd: 7, Rm: 0, Rn: 8, Imm: 0xffffffff, setflags: NEVER}},
+        // mov.w r11, #0x100
+        {instr: FetchedInstr32(0xf44f7b80), decoded: MovImmT2{Rd: 11, Rm: 0, Rn: 0, Imm: 0x100, Carry: CARRY_CLEAR, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, OrrImm32T1)
+}
+
+func TestExecuteOrrImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // orr r7, r8, #0xffffffff
+        {instr: OrrImmT1{Rd: 7, Rm: 0, Rn: 8, Imm: 0xffffffff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffffff, 8, 9, 10, 11, 12}}},
+        // orr r7, sp, #0xffffffff (UNPREDICTABLE)
+        {instr: OrrImmT1{Rd: 7, Rm: 0, Rn: SP, Imm: 0xffffffff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyOrnImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf46a797f), instr_valid: true},  // orn r9, r10, #0x3fc
+        {instr: FetchedInstr32(0xf06f0101), instr_valid: false}, // mvn r1, #1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(OrnImmT1{}))
+}
+
+func TestDecodeOrnImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // orn r9, r10, #0x3fc
+        {instr: FetchedInstr32(0xf46a797f), decoded: OrnImmT1{Rd: 9, Rm: 0, Rn: 10, Imm: 0x3fc, Carry: CARRY_CLEAR, setflags: NEVER}},
+        // mvn r1, #1
+        {instr: FetchedInstr32(0xf06f0101), decoded: MvnImmT1{Rd: 1, Rm: 0, Rn: 0, Imm: 0x1, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, OrnImm32T1)
+}
+
+func TestExecuteOrnImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // orn r9, r10, #0x3fc
+        {instr: OrnImmT1{Rd: 9, Rm: 0, Rn: 10, Imm: 0x3fc, Carry: CARRY_CLEAR, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x3, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0xfffffc03, 0x3, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMvnImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf06f0101), instr_valid: true},  // mvn r1, #1
+        {instr: FetchedInstr32(0xf07f0100), instr_valid: true},  // mvns r1, #0
+        {instr: FetchedInstr32(0xf46a797f), instr_valid: false}, // orn r9, r10, #0x3fc
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MvnImmT1{}))
+}
+
+func TestExecuteMvnImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // mvn r1, #1
+        {instr: MvnImmT1{Rd: 1, Rm: 0, Rn: 0, Imm: 0x1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xfffffffe, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // mvns r1, #0
+        {instr: MvnImmT1{Rd: 1, Rm: 0, Rn: 0, Imm: 0x0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 0xffffffff, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyEorImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf0830201), instr_valid: true},  // eor r2, r3, #1
+        {instr: FetchedInstr32(0xf0940ff0), instr_valid: false}, // teq r4, #0xf0
+    }
+
+    test_identify(t, cases, reflect.TypeOf(EorImmT1{}))
+}
+
+func TestDecodeEorImm32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // eor r2, r3, #1
+        {instr: FetchedInstr32(0xf0830201), decoded: EorImmT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0x1, setflags: NEVER}},
+        // teq r4, #0xf0
+        {instr: FetchedInstr32(0xf0940ff0), decoded: TeqImmT1{Rd: 0, Rm: 0, Rn: 4, Imm: 0xf0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, EorImm32T1)
+}
+
+func TestExecuteEorImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // eor r2, r3, #1
+        {instr: EorImmT1{Rd: 2, Rm: 0, Rn: 3, Imm: 0x1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyTeqImmT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf0940ff0), instr_valid: true},  // teq r4, #0xf0
+        {instr: FetchedInstr32(0xf0830201), instr_valid: false}, // eor r2, r3, #1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(TeqImmT1{}))
+}
+
+func TestExecuteTeqImmT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // teq r4, #0xf0
+        {instr: TeqImmT1{Rd: 0, Rm: 0, Rn: 4, Imm: 0xf0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xf0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xf0, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyAndRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea0100c2), instr_valid: true},  // and.w r0, r1, r2, lsl #3
+        {instr: FetchedInstr32(0xea101f31), instr_valid: false}, // tst.w r0, r1, ror #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AndRegT2{}))
+}
+
+func TestDecodeAndReg32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // and.w r0, r1, r2, lsl #3
+        {instr: FetchedInstr32(0xea0100c2), decoded: AndRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 3}, setflags: NEVER}},
+        // tst.w r0, r1, ror #4
+        {instr: FetchedInstr32(0xea101f31), decoded: TstRegT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 4}, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, AndReg32T2)
+}
+
+func TestExecuteAndRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // and.w r0, r1, r2, lsl #3
+        {instr: AndRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 3}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xff, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x18, 0xff, 3, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ands.w r0, r1, r2, lsl #1, carrying out of the shift
+        {instr: AndRegT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 1}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 0xffffffff, 0x80000001, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{2, 0xffffffff, 0x80000001, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+        // and.w r0, sp, r2 (UNPREDICTABLE)
+        {instr: AndRegT2{Rd: 0, Rm: 2, Rn: SP, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteTstRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // tst.w r0, r1, ror #4
+        {instr: TstRegT2{Rd: 0, Rm: 1, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_ROR, amount: 4}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0xf0000000, 0xf, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xf0000000, 0xf, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteBicRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // bic.w r3, r4, r5, lsr #1
+        {instr: BicRegT2{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_LSR, amount: 1}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xff, 2, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xfe, 0xff, 2, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyOrrRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea4706e8), instr_valid: true},  // orr.w r6, r7, r8, asr #3
+        {instr: FetchedInstr32(0xea4f0001), instr_valid: false}, // mov.w r0, r1
+        {instr: FetchedInstr32(0xea4f14c5), instr_valid: false}, // lsl.w r4, r5, #7
+    }
+
+    test_identify(t, cases, reflect.TypeOf(OrrRegT2{}))
+}
+
+func TestDecodeOrrReg32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // orr.w r6, r7, r8, asr #3
+        {instr: FetchedInstr32(0xea4706e8), decoded: OrrRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 3}, setflags: NEVER}},
+        // mov.w r0, r1
+        {instr: FetchedInstr32(0xea4f0001), decoded: MovRegT3{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER}},
+        // lsl.w r4, r5, #7
+        {instr: FetchedInstr32(0xea4f14c5), decoded: LslImmT2{Rd: 4, Rm: 5, Rn: 0, Imm: 7, setflags: NEVER}},
+        // lsrs.w r6, r7, #32
+        {instr: FetchedInstr32(0xea5f0617), decoded: LsrImmT2{Rd: 6, Rm: 7, Rn: 0, Imm: 32, setflags: ALWAYS}},
+        // asr.w r8, r9, #1
+        {instr: FetchedInstr32(0xea4f0869), decoded: AsrImmT2{Rd: 8, Rm: 9, Rn: 0, Imm: 1, setflags: NEVER}},
+        // ror r10, r11, #12
+        {instr: FetchedInstr32(0xea4f3a3b), decoded: RorImmT1{Rd: 10, Rm: 11, Rn: 0, Imm: 12, setflags: NEVER}},
+        // rrxs r12, r0
+        {instr: FetchedInstr32(0xea5f0c30), decoded: RrxT1{Rd: 12, Rm: 0, Rn: 0, Imm: 0, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, OrrReg32T2)
+}
+
+func TestExecuteOrrRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // orr.w r6, r7, r8, asr #3
+        {instr: OrrRegT2{Rd: 6, Rm: 8, Rn: 7, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 3}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 1, 0x80000000, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0xf0000001, 1, 0x80000000, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyOrnRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea612002), instr_valid: true},  // orn r0, r1, r2, lsl #8
+        {instr: FetchedInstr32(0xea6f0384), instr_valid: false}, // mvn.w r3, r4, lsl #2
+    }
+
+    test_identify(t, cases, reflect.TypeOf(OrnRegT1{}))
+}
+
+func TestExecuteOrnRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // orn r0, r1, r2, lsl #8
+        {instr: OrnRegT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 8}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 0xff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffff00ff, 0, 0xff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMvnRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea6f0384), instr_valid: true},  // mvn.w r3, r4, lsl #2
+        {instr: FetchedInstr32(0xea612002), instr_valid: false}, // orn r0, r1, r2, lsl #8
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MvnRegT2{}))
+}
+
+func TestExecuteMvnRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // mvn.w r3, r4, lsl #2
+        {instr: MvnRegT2{Rd: 3, Rm: 4, Rn: 0, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 1, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0xfffffffb, 1, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyEorRegT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea860537), instr_valid: true},  // eor.w r5, r6, r7, rrx
+        {instr: FetchedInstr32(0xea980f49), instr_valid: false}, // teq r8, r9, lsl #1
+    }
+
+    test_identify(t, cases, reflect.TypeOf(EorRegT2{}))
+}
+
+func TestExecuteEorRegT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // eor.w r5, r6, r7, rrx
+        {instr: EorRegT2{Rd: 5, Rm: 7, Rn: 6, Imm: 0, Shift: Shift{srtype: SRTYPE_RRX, amount: 1}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0, 3, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x80000001, 0, 3, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyTeqRegT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xea980f49), instr_valid: true},  // teq r8, r9, lsl #1
+        {instr: FetchedInstr32(0xea860537), instr_valid: false}, // eor.w r5, r6, r7, rrx
+    }
+
+    test_identify(t, cases, reflect.TypeOf(TeqRegT1{}))
+}
+
+func TestExecuteTeqRegT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // teq r8, r9, lsl #1
+        {instr: TeqRegT1{Rd: 0, Rm: 9, Rn: 8, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 1}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 2, 1, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 2, 1, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+        // teq r8, sp (UNPREDICTABLE)
+        {instr: TeqRegT1{Rd: 0, Rm: SP, Rn: 8, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 2, 1, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 2, 1, 10, 11, 12}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
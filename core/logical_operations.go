@@ -0,0 +1,76 @@
+package core
+
+/* Bitwise operation on the first operand and the shifted second operand */
+type BitwiseFunc func(uint32, uint32) uint32
+
+func bitwise_and(n uint32, m uint32) uint32 {
+    return n & m
+}
+
+func bitwise_eor(n uint32, m uint32) uint32 {
+    return n ^ m
+}
+
+func bitwise_orr(n uint32, m uint32) uint32 {
+    return n | m
+}
+
+func bitwise_bic(n uint32, m uint32) uint32 {
+    return n &^ m
+}
+
+func bitwise_orn(n uint32, m uint32) uint32 {
+    return n | ^m
+}
+
+/* MVN ignores the first operand */
+func bitwise_mvn(n uint32, m uint32) uint32 {
+    return ^m
+}
+
+/* Perform bitwise instruction (reg), with shift, updating condition codes */
+func BitwiseRegister(regs *Registers, instr InstrFields, shift Shift, op BitwiseFunc) {
+    shifted, carry := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result := op(regs.R(instr.Rn), shifted)
+
+    regs.SetR(instr.Rd, result)
+    if instr.setflags.ShouldSetFlags(*regs) {
+        bitwise_update_condition_codes(regs, result, carry)
+    }
+}
+
+/* Perform test instruction (reg), with shift, always updating condition codes */
+func TestRegister(regs *Registers, instr InstrFields, shift Shift, op BitwiseFunc) {
+    shifted, carry := shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+    result := op(regs.R(instr.Rn), shifted)
+
+    bitwise_update_condition_codes(regs, result, carry)
+}
+
+/* Perform bitwise instruction (imm), with the expanded modified immediate
+ * constant in Imm, updating condition codes */
+func BitwiseImmediate(regs *Registers, instr InstrFields, op BitwiseFunc) {
+    carry := instr.Carry.Evaluate(regs.Apsr.C)
+    result := op(regs.R(instr.Rn), instr.Imm)
+
+    regs.SetR(instr.Rd, result)
+    if instr.setflags.ShouldSetFlags(*regs) {
+        bitwise_update_condition_codes(regs, result, carry)
+    }
+}
+
+/* Perform test instruction (imm), with the expanded modified immediate
+ * constant in Imm, always updating condition codes */
+func TestImmediate(regs *Registers, instr InstrFields, op BitwiseFunc) {
+    carry := instr.Carry.Evaluate(regs.Apsr.C)
+    result := op(regs.R(instr.Rn), instr.Imm)
+
+    bitwise_update_condition_codes(regs, result, carry)
+}
+
+/* Update condition codes for bitwise instruction, leaving overflow alone */
+func bitwise_update_condition_codes(regs *Registers, result uint32, carry bool) {
+    regs.Apsr.N = (result & 0x80000000) != 0
+    regs.Apsr.Z = (result) == 0
+    regs.Apsr.C = carry
+}
@@ -0,0 +1,220 @@
+package core
+
+import (
+    "encoding/binary"
+    "errors"
+    "fmt"
+)
+
+/* Default memory map, matching assembly/link.ld */
+const (
+    FLASH_BASE = 0x00000000
+    FLASH_SIZE = 256 * 1024
+    RAM_BASE   = 0x20000000
+    RAM_SIZE   = 32 * 1024
+)
+
+var ErrRegionOverlap = errors.New("Memory region overlaps an existing region.")
+
+/* Little-endian, byte addressed memory */
+type Memory interface {
+    Read8(addr uint32) (uint8, error)
+    Read16(addr uint32) (uint16, error)
+    Read32(addr uint32) (uint32, error)
+    Write8(addr uint32, value uint8) error
+    Write16(addr uint32, value uint16) error
+    Write32(addr uint32, value uint32) error
+}
+
+/* Access to an address that nothing responds to */
+type BusError struct {
+    Addr  uint32
+    Size  uint32 // Access size in bytes
+    Write bool
+}
+
+func (err BusError) Error() string {
+    access := "read"
+    if err.Write {
+        access = "write"
+    }
+
+    return fmt.Sprintf("Bus error: %d-byte %s at unmapped address %#x.", err.Size, access, err.Addr)
+}
+
+/* Contiguous storage, addressed from 0 */
+type Block []byte
+
+func (block Block) check(addr uint32, size uint32, write bool) error {
+    if uint64(addr)+uint64(size) > uint64(len(block)) {
+        return BusError{Addr: addr, Size: size, Write: write}
+    }
+    return nil
+}
+
+func (block Block) Read8(addr uint32) (uint8, error) {
+    if err := block.check(addr, 1, false); err != nil {
+        return 0, err
+    }
+    return block[addr], nil
+}
+
+func (block Block) Read16(addr uint32) (uint16, error) {
+    if err := block.check(addr, 2, false); err != nil {
+        return 0, err
+    }
+    return binary.LittleEndian.Uint16(block[addr:]), nil
+}
+
+func (block Block) Read32(addr uint32) (uint32, error) {
+    if err := block.check(addr, 4, false); err != nil {
+        return 0, err
+    }
+    return binary.LittleEndian.Uint32(block[addr:]), nil
+}
+
+func (block Block) Write8(addr uint32, value uint8) error {
+    if err := block.check(addr, 1, true); err != nil {
+        return err
+    }
+    block[addr] = value
+    return nil
+}
+
+func (block Block) Write16(addr uint32, value uint16) error {
+    if err := block.check(addr, 2, true); err != nil {
+        return err
+    }
+    binary.LittleEndian.PutUint16(block[addr:], value)
+    return nil
+}
+
+func (block Block) Write32(addr uint32, value uint32) error {
+    if err := block.check(addr, 4, true); err != nil {
+        return err
+    }
+    binary.LittleEndian.PutUint32(block[addr:], value)
+    return nil
+}
+
+/* A range of the address space, backed by memory addressed relative to Base */
+type Region struct {
+    Name     string
+    Base     uint32
+    Size     uint32
+    ReadOnly bool
+    Mem      Memory
+}
+
+func (region Region) contains(addr uint32, size uint32) bool {
+    return addr >= region.Base && uint64(addr)+uint64(size) <= uint64(region.Base)+uint64(region.Size)
+}
+
+/* System bus, routing each access to the region that contains it */
+type Bus struct {
+    regions []Region
+}
+
+/* Bus with the FLASH and RAM regions from assembly/link.ld */
+func NewBus() *Bus {
+    bus := new(Bus)
+
+    bus.Map(Region{Name: "FLASH", Base: FLASH_BASE, Size: FLASH_SIZE, ReadOnly: true, Mem: make(Block, FLASH_SIZE)})
+    bus.Map(Region{Name: "RAM", Base: RAM_BASE, Size: RAM_SIZE, ReadOnly: false, Mem: make(Block, RAM_SIZE)})
+
+    return bus
+}
+
+/* Add region to the address space */
+func (bus *Bus) Map(region Region) error {
+    end := uint64(region.Base) + uint64(region.Size)
+
+    for _, mapped := range bus.regions {
+        mapped_end := uint64(mapped.Base) + uint64(mapped.Size)
+        if uint64(region.Base) < mapped_end && uint64(mapped.Base) < end {
+            return ErrRegionOverlap
+        }
+    }
+
+    bus.regions = append(bus.regions, region)
+
+    return nil
+}
+
+/* Find the region an access falls in, returning the offset into it */
+func (bus *Bus) lookup(addr uint32, size uint32, write bool) (Memory, uint32, error) {
+    for _, region := range bus.regions {
+        if region.contains(addr, size) {
+            if write && region.ReadOnly {
+                break
+            }
+            return region.Mem, addr - region.Base, nil
+        }
+    }
+
+    return nil, 0, BusError{Addr: addr, Size: size, Write: write}
+}
+
+/* Copy data into memory, ignoring whether regions are read-only */
+func (bus *Bus) Load(addr uint32, data []byte) error {
+    for i, b := range data {
+        mem, offset, err := bus.lookup(addr+uint32(i), 1, false)
+        if err != nil {
+            return BusError{Addr: addr + uint32(i), Size: 1, Write: true}
+        }
+
+        if err := mem.Write8(offset, b); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+func (bus *Bus) Read8(addr uint32) (uint8, error) {
+    mem, offset, err := bus.lookup(addr, 1, false)
+    if err != nil {
+        return 0, err
+    }
+    return mem.Read8(offset)
+}
+
+func (bus *Bus) Read16(addr uint32) (uint16, error) {
+    mem, offset, err := bus.lookup(addr, 2, false)
+    if err != nil {
+        return 0, err
+    }
+    return mem.Read16(offset)
+}
+
+func (bus *Bus) Read32(addr uint32) (uint32, error) {
+    mem, offset, err := bus.lookup(addr, 4, false)
+    if err != nil {
+        return 0, err
+    }
+    return mem.Read32(offset)
+}
+
+func (bus *Bus) Write8(addr uint32, value uint8) error {
+    mem, offset, err := bus.lookup(addr, 1, true)
+    if err != nil {
+        return err
+    }
+    return mem.Write8(offset, value)
+}
+
+func (bus *Bus) Write16(addr uint32, value uint16) error {
+    mem, offset, err := bus.lookup(addr, 2, true)
+    if err != nil {
+        return err
+    }
+    return mem.Write16(offset, value)
+}
+
+func (bus *Bus) Write32(addr uint32, value uint32) error {
+    mem, offset, err := bus.lookup(addr, 4, true)
+    if err != nil {
+        return err
+    }
+    return mem.Write32(offset, value)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+    "testing"
+)
+
+func TestBlockLittleEndian(t *testing.T) {
+    block := make(Block, 8)
+
+    block.Write32(0, 0x12345678)
+    block.Write16(4, 0xabcd)
+    block.Write8(6, 0xef)
+
+    expected := Block{0x78, 0x56, 0x34, 0x12, 0xcd, 0xab, 0xef, 0x00}
+    for i := range expected {
+        if block[i] != expected[i] {
+            t.Errorf("block: % x, expected % x", []byte(block), []byte(expected))
+            break
+        }
+    }
+
+    /* Unaligned accesses span neighbouring bytes */
+    if value, _ := block.Read32(3); value != 0xefabcd12 {
+        t.Errorf("Read32(3) = %#x, expected 0xefabcd12", value)
+    }
+
+    if value, _ := block.Read16(1); value != 0x3456 {
+        t.Errorf("Read16(1) = %#x, expected 0x3456", value)
+    }
+}
+
+func TestBusDefaultMap(t *testing.T) {
+    bus := NewBus()
+
+    cases := []struct {
+        addr  uint32
+        valid bool
+    }{
+        {addr: FLASH_BASE, valid: true},
+        {addr: FLASH_BASE + FLASH_SIZE - 4, valid: true},
+        {addr: FLASH_BASE + FLASH_SIZE - 2, valid: false}, // Straddles end of flash
+        {addr: FLASH_BASE + FLASH_SIZE, valid: false},
+        {addr: RAM_BASE - 4, valid: false},
+        {addr: RAM_BASE, valid: true},
+        {addr: RAM_BASE + RAM_SIZE - 4, valid: true},
+        {addr: RAM_BASE + RAM_SIZE, valid: false},
+        {addr: 0xfffffffe, valid: false}, // Wraps around the address space
+    }
+
+    for _, test := range cases {
+        _, err := bus.Read32(test.addr)
+
+        if test.valid && err != nil {
+            t.Errorf("Read32(%#x): %v", test.addr, err)
+        } else if !test.valid && err != (BusError{Addr: test.addr, Size: 4, Write: false}) {
+            t.Errorf("Read32(%#x): %v, expected bus error", test.addr, err)
+        }
+    }
+}
+
+func TestBusReadWrite(t *testing.T) {
+    bus := NewBus()
+
+    if err := bus.Write32(RAM_BASE+8, 0xdeadbeef); err != nil {
+        t.Fatalf("Write32: %v", err)
+    }
+
+    if value, _ := bus.Read32(RAM_BASE + 8); value != 0xdeadbeef {
+        t.Errorf("Read32 = %#x, expected 0xdeadbeef", value)
+    }
+
+    if value, _ := bus.Read16(RAM_BASE + 10); value != 0xdead {
+        t.Errorf("Read16 = %#x, expected 0xdead", value)
+    }
+
+    if value, _ := bus.Read8(RAM_BASE + 8); value != 0xef {
+        t.Errorf("Read8 = %#x, expected 0xef", value)
+    }
+}
+
+func TestBusFlashReadOnly(t *testing.T) {
+    bus := NewBus()
+
+    if err := bus.Load(FLASH_BASE, []byte{0x10, 0x20}); err != nil {
+        t.Fatalf("Load: %v", err)
+    }
+
+    if value, _ := bus.Read16(FLASH_BASE); value != 0x2010 {
+        t.Errorf("Read16 = %#x, expected 0x2010", value)
+    }
+
+    err := bus.Write16(FLASH_BASE, 0)
+    if err != (BusError{Addr: FLASH_BASE, Size: 2, Write: true}) {
+        t.Errorf("Write16 to flash: %v, expected bus error", err)
+    }
+
+    err = bus.Load(FLASH_BASE+FLASH_SIZE-1, []byte{0, 0})
+    if err != (BusError{Addr: FLASH_BASE + FLASH_SIZE, Size: 1, Write: true}) {
+        t.Errorf("Load past end of flash: %v, expected bus error", err)
+    }
+}
+
+func TestBusMapOverlap(t *testing.T) {
+    bus := NewBus()
+
+    err := bus.Map(Region{Name: "overlap", Base: RAM_BASE + RAM_SIZE - 4, Size: 8, Mem: make(Block, 8)})
+    if err != ErrRegionOverlap {
+        t.Errorf("Map overlapping RAM: %v, expected %v", err, ErrRegionOverlap)
+    }
+
+    err = bus.Map(Region{Name: "after", Base: RAM_BASE + RAM_SIZE, Size: 8, Mem: make(Block, 8)})
+    if err != nil {
+        t.Errorf("Map after RAM: %v", err)
+    }
+
+    if err := bus.Write32(RAM_BASE+RAM_SIZE+4, 1); err != nil {
+        t.Errorf("Write32 to new region: %v", err)
+    }
+}
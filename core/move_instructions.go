@@ -15,10 +15,12 @@ func MovImm16(instr FetchedInstr) DecodedInstr {
     return MovImm{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr MovImm) Execute(regs *Registers) {
+func (instr MovImm) Execute(regs *Registers, mem Memory) error {
     value := instr.Imm
 
     MoveValue(regs, instr.Rd, value, instr.setflags, regs.Apsr.C)
+
+    return nil
 }
 
 func (instr MovImm) String() string {
@@ -42,14 +44,16 @@ func MovReg16T1(instr FetchedInstr) DecodedInstr {
     return MovRegT1{Rd: d, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
 }
 
-func (instr MovRegT1) Execute(regs *Registers) {
+func (instr MovRegT1) Execute(regs *Registers, mem Memory) error {
     if instr.Rd == 15 && regs.InITBlock() && !regs.LastInITBlock() {
         // UNPREDICTABLE
         // Raise exception (UsageFault?)
-        return
+        return nil
     }
 
     MoveRegister(regs, instr.Rd, instr.Rm, instr.setflags, regs.Apsr.C)
+
+    return nil
 }
 
 func (instr MovRegT1) String() string {
@@ -70,16 +74,133 @@ func MovReg16T2(instr FetchedInstr) DecodedInstr {
     return MovRegT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: ALWAYS}
 }
 
-func (instr MovRegT2) Execute(regs *Registers) {
+func (instr MovRegT2) Execute(regs *Registers, mem Memory) error {
     if regs.InITBlock() {
         // UNPREDICTABLE
         // Raise exception (UsageFault?)
-        return
+        return nil
     }
 
     MoveRegister(regs, instr.Rd, instr.Rm, instr.setflags, regs.Apsr.C)
+
+    return nil
 }
 
 func (instr MovRegT2) String() string {
     return fmt.Sprintf("movs %s, %s", instr.Rd, instr.Rm)
 }
+
+/* MOV - Move (immediate)
+ * ARM ARM A7.7.75
+ * Encoding T2 */
+type MovImmT2 InstrFields
+
+func MovImm32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, imm12, setflags := mod_imm_fields(instr.Uint32())
+    imm32, carry := ThumbExpandImmCarry(imm12)
+
+    return MovImmT2{Rd: Rd, Rm: 0, Rn: 0, Imm: imm32, Carry: carry, setflags: setflags}
+}
+
+func (instr MovImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    MoveValue(regs, instr.Rd, instr.Imm, instr.setflags, instr.Carry.Evaluate(regs.Apsr.C))
+
+    return nil
+}
+
+func (instr MovImmT2) String() string {
+    return fmt.Sprintf("mov%s.w %s, #%d", instr.setflags, instr.Rd, instr.Imm)
+}
+
+/* Zero extend imm4:i:imm3:imm8, with imm4 in the Rn field */
+func mov_imm16(raw_instr uint32) uint32 {
+    _, imm4, imm12, _ := mod_imm_fields(raw_instr)
+    return uint32(imm4)<<12 | imm12
+}
+
+/* MOV - Move (immediate)
+ * ARM ARM A7.7.75
+ * Encoding T3 */
+type MovImmT3 InstrFields
+
+func MovImm32T3(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex((raw_instr >> 8) & 0xf)
+    Imm := mov_imm16(raw_instr)
+
+    return MovImmT3{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+}
+
+func (instr MovImmT3) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    MoveValue(regs, instr.Rd, instr.Imm, instr.setflags, regs.Apsr.C)
+
+    return nil
+}
+
+func (instr MovImmT3) String() string {
+    return fmt.Sprintf("movw %s, #%#x", instr.Rd, instr.Imm)
+}
+
+/* MOVT - Move Top
+ * ARM ARM A7.7.78 */
+type MovTopT1 InstrFields
+
+func MovTop32T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rd := RegIndex((raw_instr >> 8) & 0xf)
+    Imm := mov_imm16(raw_instr)
+
+    return MovTopT1{Rd: Rd, Rm: 0, Rn: 0, Imm: Imm, setflags: NEVER}
+}
+
+func (instr MovTopT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    value := (instr.Imm << 16) | (regs.R(instr.Rd) & 0xffff)
+    regs.SetR(instr.Rd, value)
+
+    return nil
+}
+
+func (instr MovTopT1) String() string {
+    return fmt.Sprintf("movt %s, #%#x", instr.Rd, instr.Imm)
+}
+
+/* MOV - Move (register)
+ * ARM ARM A7.7.76
+ * Encoding T3 */
+type MovRegT3 InstrFields
+
+func MovReg32T3(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, _, setflags := shifted_reg_fields(instr.Uint32())
+
+    return MovRegT3{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags}
+}
+
+func (instr MovRegT3) Execute(regs *Registers, mem Memory) error {
+    if instr.setflags == ALWAYS && (bad_reg(instr.Rd) || bad_reg(instr.Rm)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    } else if instr.Rd == PC || instr.Rm == PC || (instr.Rd == SP && instr.Rm == SP) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    MoveRegister(regs, instr.Rd, instr.Rm, instr.setflags, regs.Apsr.C)
+
+    return nil
+}
+
+func (instr MovRegT3) String() string {
+    return fmt.Sprintf("mov%s.w %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
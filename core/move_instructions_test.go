@@ -83,11 +83,11 @@ func TestExecuteMovRegT1(t *testing.T) {
         // mov pc, r12
         {instr: MovRegT1{Rd: 15, Rm: 12, Rn: 0, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xDEAD, Apsr: Apsr{C: true}},
-            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xCAFE, Apsr: Apsr{C: true}}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFE}, pc: 0xCAFE, branched: true, Apsr: Apsr{C: true}}},
         // mov pc, r12
         {instr: MovRegT1{Rd: 15, Rm: 12, Rn: 0, Imm: 0, setflags: NEVER},
             regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xDEAD, Apsr: Apsr{C: true}},
-            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xCAFE, Apsr: Apsr{C: true}}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xCAFF}, pc: 0xCAFE, branched: true, Apsr: Apsr{C: true}}},
     }
 
     test_execute(t, cases)
@@ -131,3 +131,141 @@ func TestExecuteMovRegT2(t *testing.T) {
 
     test_execute(t, cases)
 }
+
+func TestIdentifyMovImmT2(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf44f7b80), instr_valid: true},  // mov.w r11, #0x100
+        {instr: FetchedInstr32(0xf05f407f), instr_valid: true},  // movs.w r0, #0xff000000
+        {instr: FetchedInstr32(0xf04837ff), instr_valid: false}, // orr r7, r8, #0xffffffff
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MovImmT2{}))
+}
+
+func TestDecodeMovImm32T2(t *testing.T) {
+    cases := []DecodeCase{
+        // mov.w r11, #0x100
+        {instr: FetchedInstr32(0xf44f7b80), decoded: MovImmT2{Rd: 11, Rm: 0, Rn: 0, Imm: 0x100, Carry: CARRY_CLEAR, setflags: NEVER}},
+        // movs.w r0, #0xff000000
+        {instr: FetchedInstr32(0xf05f407f), decoded: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0xff000000, Carry: CARRY_SET, setflags: ALWAYS}},
+    }
+
+    test_decode(t, cases, MovImm32T2)
+}
+
+func TestExecuteMovImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // mov.w r11, #0x100
+        {instr: MovImmT2{Rd: 11, Rm: 0, Rn: 0, Imm: 0x100, Carry: CARRY_CLEAR, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0x100, 12}}},
+        // movs.w r0, #0xff000000
+        {instr: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0xff000000, Carry: CARRY_SET, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}},
+            expected: Registers{r: GeneralRegs{0xff000000, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true, C: true}}},
+        // movs.w r0, #0x100, sets flags even in an IT block
+        {instr: MovImmT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0x100, Carry: CARRY_CLEAR, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0x100, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}}},
+        // mov.w pc, #0x100 (UNPREDICTABLE)
+        {instr: MovImmT2{Rd: PC, Rm: 0, Rn: 0, Imm: 0x100, Carry: CARRY_CLEAR, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMovImmT3(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf2412534), instr_valid: true},  // movw r5, #0x1234
+        {instr: FetchedInstr32(0xf64f7cff), instr_valid: true},  // movw r12, #0xffff
+        {instr: FetchedInstr32(0xf2c40502), instr_valid: false}, // movt r5, #0x4002
+        {instr: FetchedInstr32(0xf44f7b80), instr_valid: false}, // mov.w r11, #0x100
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MovImmT3{}))
+}
+
+func TestDecodeMovImm32T3(t *testing.T) {
+    cases := []DecodeCase{
+        // movw r5, #0x1234
+        {instr: FetchedInstr32(0xf2412534), decoded: MovImmT3{Rd: 5, Rm: 0, Rn: 0, Imm: 0x1234, setflags: NEVER}},
+        // movw r12, #0xffff
+        {instr: FetchedInstr32(0xf64f7cff), decoded: MovImmT3{Rd: 12, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, MovImm32T3)
+}
+
+func TestExecuteMovImmT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // movw r12, #0xffff, clearing the top halfword and leaving flags alone
+        {instr: MovImmT3{Rd: 12, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xabcd0000}, Apsr: Apsr{Z: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0xffff}, Apsr: Apsr{Z: true}}},
+        // movw sp, #0xffff (UNPREDICTABLE)
+        {instr: MovImmT3{Rd: SP, Rm: 0, Rn: 0, Imm: 0xffff, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMovTopT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf2c40502), instr_valid: true},  // movt r5, #0x4002
+        {instr: FetchedInstr32(0xf2c00000), instr_valid: true},  // movt r0, #0
+        {instr: FetchedInstr32(0xf2412534), instr_valid: false}, // movw r5, #0x1234
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MovTopT1{}))
+}
+
+func TestDecodeMovTop32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // movt r5, #0x4002
+        {instr: FetchedInstr32(0xf2c40502), decoded: MovTopT1{Rd: 5, Rm: 0, Rn: 0, Imm: 0x4002, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, MovTop32T1)
+}
+
+func TestExecuteMovTopT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // movt r5, #0x4002, keeping the bottom halfword
+        {instr: MovTopT1{Rd: 5, Rm: 0, Rn: 0, Imm: 0x4002, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xffff1234, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x40021234, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteMovRegT3(t *testing.T) {
+    cases := []ExecuteCase{
+        // mov.w r0, r1
+        {instr: MovRegT3{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // movs.w r2, r3
+        {instr: MovRegT3{Rd: 2, Rm: 3, Rn: 0, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0, 0, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true}}},
+        // mov.w sp, r1
+        {instr: MovRegT3{Rd: SP, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{1: 0x20001000}},
+            expected: Registers{r: GeneralRegs{1: 0x20001000}, sp: SPRegs{0x20001000, 0}}},
+        // movs.w sp, r1 (UNPREDICTABLE)
+        {instr: MovRegT3{Rd: SP, Rm: 1, Rn: 0, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{1: 0x20001000}},
+            expected: Registers{r: GeneralRegs{1: 0x20001000}}},
+        // mov.w pc, r1 (UNPREDICTABLE)
+        {instr: MovRegT3{Rd: PC, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{1: 0x1000}},
+            expected: Registers{r: GeneralRegs{1: 0x1000}}},
+    }
+
+    test_execute(t, cases)
+}
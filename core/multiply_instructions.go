@@ -0,0 +1,266 @@
+package core
+
+import "fmt"
+
+/* MUL - Multiply
+ * ARM ARM A7.7.83
+ * Encoding T1 */
+type MulT1 InstrFields
+
+func Mul16T1(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdm := RegIndex(raw_instr & 0x7)
+    Rn := RegIndex((raw_instr >> 3) & 0x7)
+
+    return MulT1{Rd: Rdm, Rm: Rdm, Rn: Rn, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr MulT1) Execute(regs *Registers, mem Memory) error {
+    Multiply(regs, InstrFields(instr))
+    return nil
+}
+
+func (instr MulT1) String() string {
+    return fmt.Sprintf("mul%s %s, %s, %s", instr.setflags, instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* Fields shared by the 32-bit multiply and divide encodings, where the
+ * register in bits [15:12] is either Ra or RdLo */
+func multiply_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, Rm RegIndex, Ra RegIndex) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    Rm = RegIndex(raw_instr & 0xf)
+    Ra = RegIndex((raw_instr >> 12) & 0xf)
+
+    return Rd, Rn, Rm, Ra
+}
+
+/* MUL - Multiply
+ * ARM ARM A7.7.83
+ * Encoding T2 */
+type MulT2 InstrFields
+
+func Mul32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, _ := multiply_fields(instr.Uint32())
+
+    return MulT2{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr MulT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Multiply(regs, InstrFields(instr))
+
+    return nil
+}
+
+func (instr MulT2) String() string {
+    return fmt.Sprintf("mul %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* MLA - Multiply Accumulate
+ * ARM ARM A7.7.73
+ * Encoding T1 */
+type MlaT1 InstrFields
+
+func Mla32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, Ra := multiply_fields(instr.Uint32())
+
+    if Ra == PC {
+        return Mul32T2(instr)
+    }
+
+    return MlaT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Ra: Ra, setflags: NEVER}
+}
+
+func (instr MlaT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) || instr.Ra == SP {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    MultiplyAccumulate(regs, InstrFields(instr), false)
+
+    return nil
+}
+
+func (instr MlaT1) String() string {
+    return fmt.Sprintf("mla %s, %s, %s, %s", instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* MLS - Multiply and Subtract
+ * ARM ARM A7.7.74
+ * Encoding T1 */
+type MlsT1 InstrFields
+
+func Mls32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, Ra := multiply_fields(instr.Uint32())
+
+    return MlsT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Ra: Ra, setflags: NEVER}
+}
+
+func (instr MlsT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) || bad_reg(instr.Ra) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    MultiplyAccumulate(regs, InstrFields(instr), true)
+
+    return nil
+}
+
+func (instr MlsT1) String() string {
+    return fmt.Sprintf("mls %s, %s, %s, %s", instr.Rd, instr.Rn, instr.Rm, instr.Ra)
+}
+
+/* Decode a long multiply, with RdLo in Rd */
+func long_multiply(instr FetchedInstr) InstrFields {
+    RdHi, Rn, Rm, RdLo := multiply_fields(instr.Uint32())
+
+    return InstrFields{Rd: RdLo, Rm: Rm, Rn: Rn, Imm: 0, RdHi: RdHi, setflags: NEVER}
+}
+
+/* Whether the registers of a long multiply are UNPREDICTABLE */
+func long_multiply_unpredictable(instr InstrFields) bool {
+    return bad_reg(instr.Rd) || bad_reg(instr.RdHi) || bad_reg(instr.Rn) || bad_reg(instr.Rm) || instr.Rd == instr.RdHi
+}
+
+/* SMULL - Signed Multiply Long
+ * ARM ARM A7.7.147
+ * Encoding T1 */
+type SmullT1 InstrFields
+
+func Smull32T1(instr FetchedInstr) DecodedInstr {
+    return SmullT1(long_multiply(instr))
+}
+
+func (instr SmullT1) Execute(regs *Registers, mem Memory) error {
+    if long_multiply_unpredictable(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    LongMultiply(regs, InstrFields(instr), true, false)
+
+    return nil
+}
+
+func (instr SmullT1) String() string {
+    return fmt.Sprintf("smull %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* UMULL - Unsigned Multiply Long
+ * ARM ARM A7.7.204
+ * Encoding T1 */
+type UmullT1 InstrFields
+
+func Umull32T1(instr FetchedInstr) DecodedInstr {
+    return UmullT1(long_multiply(instr))
+}
+
+func (instr UmullT1) Execute(regs *Registers, mem Memory) error {
+    if long_multiply_unpredictable(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    LongMultiply(regs, InstrFields(instr), false, false)
+
+    return nil
+}
+
+func (instr UmullT1) String() string {
+    return fmt.Sprintf("umull %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SMLAL - Signed Multiply Accumulate Long
+ * ARM ARM A7.7.136
+ * Encoding T1 */
+type SmlalT1 InstrFields
+
+func Smlal32T1(instr FetchedInstr) DecodedInstr {
+    return SmlalT1(long_multiply(instr))
+}
+
+func (instr SmlalT1) Execute(regs *Registers, mem Memory) error {
+    if long_multiply_unpredictable(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    LongMultiply(regs, InstrFields(instr), true, true)
+
+    return nil
+}
+
+func (instr SmlalT1) String() string {
+    return fmt.Sprintf("smlal %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* UMLAL - Unsigned Multiply Accumulate Long
+ * ARM ARM A7.7.203
+ * Encoding T1 */
+type UmlalT1 InstrFields
+
+func Umlal32T1(instr FetchedInstr) DecodedInstr {
+    return UmlalT1(long_multiply(instr))
+}
+
+func (instr UmlalT1) Execute(regs *Registers, mem Memory) error {
+    if long_multiply_unpredictable(InstrFields(instr)) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    LongMultiply(regs, InstrFields(instr), false, true)
+
+    return nil
+}
+
+func (instr UmlalT1) String() string {
+    return fmt.Sprintf("umlal %s, %s, %s, %s", instr.Rd, instr.RdHi, instr.Rn, instr.Rm)
+}
+
+/* SDIV - Signed Divide
+ * ARM ARM A7.7.125
+ * Encoding T1 */
+type SdivT1 InstrFields
+
+func Sdiv32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, _ := multiply_fields(instr.Uint32())
+
+    return SdivT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr SdivT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return Divide(regs, InstrFields(instr), true)
+}
+
+func (instr SdivT1) String() string {
+    return fmt.Sprintf("sdiv %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
+
+/* UDIV - Unsigned Divide
+ * ARM ARM A7.7.195
+ * Encoding T1 */
+type UdivT1 InstrFields
+
+func Udiv32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, _ := multiply_fields(instr.Uint32())
+
+    return UdivT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, setflags: NEVER}
+}
+
+func (instr UdivT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    return Divide(regs, InstrFields(instr), false)
+}
+
+func (instr UdivT1) String() string {
+    return fmt.Sprintf("udiv %s, %s, %s", instr.Rd, instr.Rn, instr.Rm)
+}
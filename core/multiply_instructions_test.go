@@ -0,0 +1,261 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyMulT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x4348), instr_valid: true},  // muls r0, r1, r0
+        {instr: FetchedInstr16(0x43ac), instr_valid: false}, // bics r4, r5
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MulT1{}))
+}
+
+func TestDecodeMul16T1(t *testing.T) {
+    cases := []DecodeCase{
+        // muls r0, r1, r0
+        {instr: FetchedInstr16(0x4348), decoded: MulT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, Mul16T1)
+}
+
+func TestExecuteMulT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // muls r0, r1, r0
+        {instr: MulT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{6, 7, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{42, 7, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // muls r0, r1, r0, only the low word is kept and carry and overflow are unaffected
+        {instr: MulT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x10000, 0x10000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true, V: true}},
+            expected: Registers{r: GeneralRegs{0, 0x10000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true, V: true}}},
+        // muls r0, r1, r0, negative
+        {instr: MulT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xfffffffd, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{N: true}}},
+        // muls r0, r1, r0, in an IT block
+        {instr: MulT1{Rd: 0, Rm: 0, Rn: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}},
+            expected: Registers{r: GeneralRegs{0, 3, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Epsr: Epsr{IT: 0x08}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyMlaT1(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xfb046305), instr_valid: true},  // mla r3, r4, r5, r6
+        {instr: FetchedInstr32(0xfb01f002), instr_valid: false}, // mul r0, r1, r2
+        {instr: FetchedInstr32(0xfb08a719), instr_valid: false}, // mls r7, r8, r9, r10
+    }
+
+    test_identify(t, cases, reflect.TypeOf(MlaT1{}))
+}
+
+func TestDecodeMla32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // mla r3, r4, r5, r6
+        {instr: FetchedInstr32(0xfb046305), decoded: MlaT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Ra: 6, setflags: NEVER}},
+        // mul r0, r1, r2
+        {instr: FetchedInstr32(0xfb01f002), decoded: MulT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Mla32T1)
+}
+
+func TestExecuteMulT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // mul r0, r1, r2, leaving the flags alone
+        {instr: MulT2{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x10000, 0x10000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0x10000, 0x10000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // mul r0, sp, r2 (UNPREDICTABLE)
+        {instr: MulT2{Rd: 0, Rm: 2, Rn: SP, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteMlaT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // mla r3, r4, r5, r6
+        {instr: MlaT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Ra: 6, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 26, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // mla r3, r4, r5, r6, keeping the low word
+        {instr: MlaT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Ra: 6, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xffffffff, 2, 3, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 1, 0xffffffff, 2, 3, 7, 8, 9, 10, 11, 12}}},
+        // mla r3, r4, r5, sp (UNPREDICTABLE)
+        {instr: MlaT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Ra: SP, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteMlsT1(t *testing.T) {
+    cases := []ExecuteCase{
+        // mls r7, r8, r9, r10
+        {instr: MlsT1{Rd: 7, Rm: 9, Rn: 8, Imm: 0, Ra: 10, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffffc2, 8, 9, 10, 11, 12}}},
+        // mls r7, r8, r9, pc (UNPREDICTABLE)
+        {instr: MlsT1{Rd: 7, Rm: 9, Rn: 8, Imm: 0, Ra: PC, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyLongMultiply(t *testing.T) {
+    cases := []struct {
+        instr      FetchedInstr32
+        instr_type reflect.Type
+    }{
+        {instr: 0xfb820103, instr_type: reflect.TypeOf(SmullT1{})}, // smull r0, r1, r2, r3
+        {instr: 0xfba64507, instr_type: reflect.TypeOf(UmullT1{})}, // umull r4, r5, r6, r7
+        {instr: 0xfbca890b, instr_type: reflect.TypeOf(SmlalT1{})}, // smlal r8, r9, r10, r11
+        {instr: 0xfbe1c002, instr_type: reflect.TypeOf(UmlalT1{})}, // umlal r12, r0, r1, r2
+        {instr: 0xfb91f0f2, instr_type: reflect.TypeOf(SdivT1{})},  // sdiv r0, r1, r2
+        {instr: 0xfbb4f3f5, instr_type: reflect.TypeOf(UdivT1{})},  // udiv r3, r4, r5
+    }
+
+    for _, test := range cases {
+        test_identify(t, []IdentifyCase{{instr: test.instr, instr_valid: true}}, test.instr_type)
+    }
+}
+
+func TestDecodeSmull32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // smull r0, r1, r2, r3
+        {instr: FetchedInstr32(0xfb820103), decoded: SmullT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, RdHi: 1, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Smull32T1)
+}
+
+func TestExecuteLongMultiply(t *testing.T) {
+    cases := []ExecuteCase{
+        // smull r0, r1, r2, r3
+        {instr: SmullT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, RdHi: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 0xffffffff, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x80000000, 0, 0xffffffff, 0x80000000, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // umull r4, r5, r6, r7
+        {instr: UmullT1{Rd: 4, Rm: 7, Rn: 6, Imm: 0, RdHi: 5, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0xffffffff, 0xffffffff, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 1, 0xfffffffe, 0xffffffff, 0xffffffff, 8, 9, 10, 11, 12}}},
+        // smlal r8, r9, r10, r11
+        {instr: SmlalT1{Rd: 8, Rm: 11, Rn: 10, Imm: 0, RdHi: 9, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0xffffffff, 0, 0xffffffff, 1, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0xfffffffe, 0, 0xffffffff, 1, 12}}},
+        // umlal r12, r0, r1, r2, carrying into the high word
+        {instr: UmlalT1{Rd: 12, Rm: 2, Rn: 1, Imm: 0, RdHi: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{1, 1, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x80000000}},
+            expected: Registers{r: GeneralRegs{2, 1, 0x80000000, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0}}},
+        // smull r0, r0, r2, r3 (UNPREDICTABLE)
+        {instr: SmullT1{Rd: 0, Rm: 3, Rn: 2, Imm: 0, RdHi: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // umull r4, sp, r6, r7 (UNPREDICTABLE)
+        {instr: UmullT1{Rd: 4, Rm: 7, Rn: 6, Imm: 0, RdHi: SP, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteDivide(t *testing.T) {
+    cases := []ExecuteCase{
+        // sdiv r0, r1, r2, rounding towards zero
+        {instr: SdivT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xfffffff9, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xfffffffd, 0xfffffff9, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sdiv r0, r1, r2, overflowing
+        {instr: SdivT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x80000000, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x80000000, 0x80000000, 0xffffffff, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // sdiv r0, r1, r2, by zero, resolved by the processor
+        {instr: SdivT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{7, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{7, 1, 0, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            err:      DivideByZero{Rd: 0}},
+        // udiv r3, r4, r5
+        {instr: UdivT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0xfffffff9, 2, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x7ffffffc, 0xfffffff9, 2, 6, 7, 8, 9, 10, 11, 12}}},
+        // udiv r3, r4, r5, by zero, resolved by the processor
+        {instr: UdivT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0, 6, 7, 8, 9, 10, 11, 12}},
+            err:      DivideByZero{Rd: 3}},
+        // udiv r3, r4, pc (UNPREDICTABLE)
+        {instr: UdivT1{Rd: 3, Rm: PC, Rn: 4, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestDivideByZeroTrap(t *testing.T) {
+    cpu := exception_cpu(t, []uint16{0xe7fe}, []uint16{0xe7fe}, []uint16{
+        0xfbb4, 0xf3f5, // udiv r3, r4, r5
+        0xfbb4, 0xf3f5, // udiv r3, r4, r5
+    })
+
+    cpu.Regs.SetR(3, 3)
+    if err := cpu.Step(); err != nil || cpu.Regs.R(3) != 0 || cpu.Regs.Mode != MODE_THREAD {
+        t.Errorf("Without DIV_0_TRP: err %v\n%s", err, cpu.Regs.Pretty())
+    }
+
+    /* The trap is taken from CCR as the instruction executes */
+    cpu.Scs.Ccr |= CCR_DIV_0_TRP
+    cpu.Regs.SetR(3, 3)
+    if err := cpu.Step(); err != nil || cpu.Regs.R(3) != 3 || cpu.Regs.Ipsr.ExcpNum != EXC_HARD_FAULT {
+        t.Errorf("With DIV_0_TRP: err %v\n%s", err, cpu.Regs.Pretty())
+    }
+
+    if cpu.Scs.Cfsr != uint32(UFSR_DIVBYZERO)<<16 {
+        t.Errorf("CFSR = %#x", cpu.Scs.Cfsr)
+    }
+}
+
+func TestStringMultiply32(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xfb01f002, expected: "mul r0, r1, r2"},
+        {instr: 0xfb046305, expected: "mla r3, r4, r5, r6"},
+        {instr: 0xfb08a719, expected: "mls r7, r8, r9, r10"},
+        {instr: 0xfb820103, expected: "smull r0, r1, r2, r3"},
+        {instr: 0xfba64507, expected: "umull r4, r5, r6, r7"},
+        {instr: 0xfbca890b, expected: "smlal r8, r9, r10, r11"},
+        {instr: 0xfbe1c002, expected: "umlal r12, r0, r1, r2"},
+        {instr: 0xfb91f0f2, expected: "sdiv r0, r1, r2"},
+        {instr: 0xfbb4f3f5, expected: "udiv r3, r4, r5"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes32.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
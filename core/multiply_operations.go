@@ -0,0 +1,77 @@
+package core
+
+import (
+    "fmt"
+)
+
+/* Division by zero, which the processor resolves from CCR.DIV_0_TRP: either
+ * a UsageFault, or a quotient of 0 written to Rd */
+type DivideByZero struct {
+    Rd RegIndex
+}
+
+func (err DivideByZero) Error() string {
+    return fmt.Sprintf("Divide by zero into r%d.", err.Rd)
+}
+
+/* Perform multiply instruction, keeping the low 32 bits of the product and
+ * updating the N and Z condition codes */
+func Multiply(regs *Registers, instr InstrFields) {
+    result := regs.R(instr.Rn) * regs.R(instr.Rm)
+
+    regs.SetR(instr.Rd, result)
+    if instr.setflags.ShouldSetFlags(*regs) {
+        regs.Apsr.N = (result & 0x80000000) != 0
+        regs.Apsr.Z = (result) == 0
+    }
+}
+
+/* Perform multiply accumulate instruction, adding the low 32 bits of the
+ * product to Ra, or subtracting them from Ra */
+func MultiplyAccumulate(regs *Registers, instr InstrFields, subtract bool) {
+    product := regs.R(instr.Rn) * regs.R(instr.Rm)
+
+    if subtract {
+        regs.SetR(instr.Rd, regs.R(instr.Ra)-product)
+    } else {
+        regs.SetR(instr.Rd, regs.R(instr.Ra)+product)
+    }
+}
+
+/* Perform long multiply instruction, writing the 64-bit product to RdHi:Rd
+ * and adding it to their previous value if accumulate */
+func LongMultiply(regs *Registers, instr InstrFields, signed bool, accumulate bool) {
+    var result uint64
+
+    if signed {
+        result = uint64(int64(int32(regs.R(instr.Rn))) * int64(int32(regs.R(instr.Rm))))
+    } else {
+        result = uint64(regs.R(instr.Rn)) * uint64(regs.R(instr.Rm))
+    }
+
+    if accumulate {
+        result += uint64(regs.R(instr.RdHi))<<32 | uint64(regs.R(instr.Rd))
+    }
+
+    regs.SetR(instr.RdHi, uint32(result>>32))
+    regs.SetR(instr.Rd, uint32(result))
+}
+
+/* Perform divide instruction, rounding towards zero.  Divide by zero
+ * leaves Rd unchanged and returns DivideByZero. */
+func Divide(regs *Registers, instr InstrFields, signed bool) error {
+    n, m := regs.R(instr.Rn), regs.R(instr.Rm)
+
+    if m == 0 {
+        return DivideByZero{Rd: instr.Rd}
+    }
+
+    if signed {
+        /* The most negative value divided by -1 overflows back to itself */
+        regs.SetR(instr.Rd, uint32(int32(n)/int32(m)))
+    } else {
+        regs.SetR(instr.Rd, n/m)
+    }
+
+    return nil
+}
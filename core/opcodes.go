@@ -1,5 +1,12 @@
 package core
 
+import (
+    "fmt"
+    "reflect"
+    "runtime"
+    "strings"
+)
+
 type Opcode struct {
     mask  uint32
     value uint32
@@ -11,22 +18,519 @@ func (op *Opcode) Match(instr FetchedInstr) bool {
     return (raw_instr & op.mask) == op.value
 }
 
-var InstrOpcodes16 = map[Opcode]DecodeFunc{
-    Opcode{mask: 0xf800, value: 0x0000}: LslImm16,
-    Opcode{mask: 0xffc0, value: 0x4080}: LslReg16,
-    Opcode{mask: 0xf800, value: 0x0800}: LsrImm16,
-    Opcode{mask: 0xffc0, value: 0x40c0}: LsrReg16,
-    Opcode{mask: 0xf800, value: 0x1000}: AsrImm16,
-    Opcode{mask: 0xf800, value: 0x2000}: MovImm16,
-    Opcode{mask: 0xff00, value: 0x4600}: MovReg16T1,
-    Opcode{mask: 0xffc0, value: 0x0000}: MovReg16T2,
-    Opcode{mask: 0xfe00, value: 0x1800}: AddReg16T1,
-    Opcode{mask: 0xff00, value: 0x4400}: AddReg16T2,
-    Opcode{mask: 0xff78, value: 0x4468}: AddRegSP16T1,
-    Opcode{mask: 0xff87, value: 0x4485}: AddRegSP16T2,
-    Opcode{mask: 0xfe00, value: 0x1a00}: SubReg16T1,
-    Opcode{mask: 0xfe00, value: 0x1c00}: AddImm16T1,
-    Opcode{mask: 0xf800, value: 0x3000}: AddImm16T2,
-}
-
-var InstrOpcodes32 = map[Opcode]DecodeFunc{}
+/* Whether some instruction could match both opcodes */
+func (op *Opcode) Overlaps(other Opcode) bool {
+    return ((op.value ^ other.value) & op.mask & other.mask) == 0
+}
+
+/* One row of an encoding table, which either decodes an instruction
+ * or refers to a more specific table */
+type DecodeEntry struct {
+    Opcode
+    decode DecodeFunc
+    table  *DecodeTable
+}
+
+func (entry DecodeEntry) String() string {
+    if entry.table != nil {
+        return fmt.Sprintf("%q (mask %#x, value %#x)", entry.table.name, entry.mask, entry.value)
+    }
+
+    name := runtime.FuncForPC(reflect.ValueOf(entry.decode).Pointer()).Name()
+    name = name[strings.LastIndex(name, ".")+1:]
+
+    return fmt.Sprintf("%s (mask %#x, value %#x)", name, entry.mask, entry.value)
+}
+
+/* Encoding table, following one of the tables in ARMv7-M ARM A5.
+ *
+ * The table is indexed by the opcode bits in key, which pick out the few
+ * entries that can match.  No two entries may match the same instruction,
+ * so the order of the entries never matters. */
+type DecodeTable struct {
+    name    string
+    key     uint32
+    entries []DecodeEntry
+    bits    []uint // Bit positions of key, least significant first
+    slots   [][]DecodeEntry
+}
+
+func (table *DecodeTable) index(raw_instr uint32) uint32 {
+    var index uint32
+
+    for i, bit := range table.bits {
+        index |= ((raw_instr >> bit) & 0x1) << uint(i)
+    }
+
+    return index
+}
+
+/* Sort the entries into slots by key, and check that no two entries overlap */
+func (table *DecodeTable) Build() error {
+    if table.slots != nil {
+        return nil
+    }
+
+    for bit := uint(0); bit < 32; bit++ {
+        if (table.key>>bit)&0x1 != 0 {
+            table.bits = append(table.bits, bit)
+        }
+    }
+
+    table.slots = make([][]DecodeEntry, 1<<uint(len(table.bits)))
+
+    var overlaps []string
+    reported := make(map[[2]int]bool)
+
+    for index := range table.slots {
+        var key_value uint32
+        var candidates []int
+
+        for i, bit := range table.bits {
+            key_value |= ((uint32(index) >> uint(i)) & 0x1) << bit
+        }
+
+        for i, entry := range table.entries {
+            if ((key_value ^ entry.value) & entry.mask & table.key) == 0 {
+                for _, j := range candidates {
+                    if table.entries[j].Overlaps(entry.Opcode) && !reported[[2]int{j, i}] {
+                        reported[[2]int{j, i}] = true
+                        overlaps = append(overlaps, fmt.Sprintf("%s: %s overlaps %s",
+                            table.name, table.entries[j], entry))
+                    }
+                }
+
+                candidates = append(candidates, i)
+                table.slots[index] = append(table.slots[index], entry)
+            }
+        }
+    }
+
+    for _, entry := range table.entries {
+        if entry.table != nil {
+            if err := entry.table.Build(); err != nil {
+                overlaps = append(overlaps, err.Error())
+            }
+        }
+    }
+
+    if overlaps != nil {
+        return fmt.Errorf("%s", strings.Join(overlaps, "\n"))
+    }
+
+    return nil
+}
+
+func (table *DecodeTable) Decode(instr FetchedInstr) (DecodedInstr, error) {
+    for _, entry := range table.slots[table.index(instr.Uint32())] {
+        if entry.Match(instr) {
+            if entry.table != nil {
+                return entry.table.Decode(instr)
+            }
+            return entry.decode(instr), nil
+        }
+    }
+
+    return UndefinedInstr{}, ErrUndefinedInstruction
+}
+
+/* 16-bit Thumb instruction encoding
+ * ARMv7-M ARM A5.2 */
+var InstrOpcodes16 = &DecodeTable{
+    name: "16-bit Thumb instruction encoding",
+    key:  0xfc00,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xc000, value: 0x0000}, table: shift_add_sub_mov_cmp16},
+        {Opcode: Opcode{mask: 0xfc00, value: 0x4000}, table: data_processing16},
+        {Opcode: Opcode{mask: 0xfc00, value: 0x4400}, table: special_data_branch_exchange16},
+        {Opcode: Opcode{mask: 0xf800, value: 0x4800}, decode: LdrLit16T1},
+        {Opcode: Opcode{mask: 0xf000, value: 0x5000}, table: load_store_single16},
+        {Opcode: Opcode{mask: 0xe000, value: 0x6000}, table: load_store_single16},
+        {Opcode: Opcode{mask: 0xe000, value: 0x8000}, table: load_store_single16},
+        {Opcode: Opcode{mask: 0xf800, value: 0xa000}, decode: Adr16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0xa800}, decode: AddImmSP16T1},
+        {Opcode: Opcode{mask: 0xf000, value: 0xb000}, table: misc16},
+        {Opcode: Opcode{mask: 0xf800, value: 0xc000}, decode: Stm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0xc800}, decode: Ldm16T1},
+        {Opcode: Opcode{mask: 0xf000, value: 0xd000}, table: cond_branch_svc16},
+        {Opcode: Opcode{mask: 0xf800, value: 0xe000}, decode: Branch16T2},
+    },
+}
+
+/* Shift (immediate), add, subtract, move, and compare
+ * ARMv7-M ARM A5.2.1 */
+var shift_add_sub_mov_cmp16 = &DecodeTable{
+    name: "Shift (immediate), add, subtract, move, and compare",
+    key:  0x3e00,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xf800, value: 0x0000}, decode: LslImm16}, // MOV (register) T2 when imm5 is 0
+        {Opcode: Opcode{mask: 0xf800, value: 0x0800}, decode: LsrImm16},
+        {Opcode: Opcode{mask: 0xf800, value: 0x1000}, decode: AsrImm16},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x1800}, decode: AddReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x1a00}, decode: SubReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x1c00}, decode: AddImm16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x1e00}, decode: SubImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x2000}, decode: MovImm16},
+        {Opcode: Opcode{mask: 0xf800, value: 0x2800}, decode: CmpImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x3000}, decode: AddImm16T2},
+        {Opcode: Opcode{mask: 0xf800, value: 0x3800}, decode: SubImm16T2},
+    },
+}
+
+/* Data processing
+ * ARMv7-M ARM A5.2.2 */
+var data_processing16 = &DecodeTable{
+    name: "Data processing",
+    key:  0x03c0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4000}, decode: AndReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4040}, decode: EorReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4080}, decode: LslReg16},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x40c0}, decode: LsrReg16},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4100}, decode: AsrReg16},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4140}, decode: AdcReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4180}, decode: SbcReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x41c0}, decode: RorReg16},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4200}, decode: TstReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4240}, decode: RsbImm16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4280}, decode: CmpReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x42c0}, decode: CmnReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4300}, decode: OrrReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4340}, decode: Mul16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x4380}, decode: BicReg16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0x43c0}, decode: MvnReg16T1},
+    },
+}
+
+/* Special data instructions and branch and exchange
+ * ARMv7-M ARM A5.2.3 */
+var special_data_branch_exchange16 = &DecodeTable{
+    name: "Special data instructions and branch and exchange",
+    key:  0x03c0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xff00, value: 0x4400}, decode: AddReg16T2}, // ADD (SP plus register) when either register is SP
+        {Opcode: Opcode{mask: 0xff00, value: 0x4500}, decode: CmpReg16T2},
+        {Opcode: Opcode{mask: 0xff00, value: 0x4600}, decode: MovReg16T1},
+        {Opcode: Opcode{mask: 0xff80, value: 0x4700}, decode: BranchExchange16},
+        {Opcode: Opcode{mask: 0xff80, value: 0x4780}, decode: BranchLinkExchange16},
+    },
+}
+
+/* Load/store single data item
+ * ARMv7-M ARM A5.2.4 */
+var load_store_single16 = &DecodeTable{
+    name: "Load/store single data item",
+    key:  0xfe00,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5000}, decode: StrReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5200}, decode: StrhReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5400}, decode: StrbReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5600}, decode: LdrsbReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5800}, decode: LdrReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5a00}, decode: LdrhReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5c00}, decode: LdrbReg16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0x5e00}, decode: LdrshReg16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x6000}, decode: StrImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x6800}, decode: LdrImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x7000}, decode: StrbImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x7800}, decode: LdrbImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x8000}, decode: StrhImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x8800}, decode: LdrhImm16T1},
+        {Opcode: Opcode{mask: 0xf800, value: 0x9000}, decode: StrImm16T2},
+        {Opcode: Opcode{mask: 0xf800, value: 0x9800}, decode: LdrImm16T2},
+    },
+}
+
+/* Miscellaneous 16-bit instructions
+ * ARMv7-M ARM A5.2.5 */
+var misc16 = &DecodeTable{
+    name: "Miscellaneous 16-bit instructions",
+    key:  0x0fe0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xff80, value: 0xb000}, decode: AddImmSP16T2},
+        {Opcode: Opcode{mask: 0xff80, value: 0xb080}, decode: SubImmSP16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0xb400}, decode: Push16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0xbc00}, decode: Pop16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xb200}, decode: Sxth16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xb240}, decode: Sxtb16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xb280}, decode: Uxth16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xb2c0}, decode: Uxtb16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xba00}, decode: Rev16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xba40}, decode: RevHalfwords16T1},
+        {Opcode: Opcode{mask: 0xffc0, value: 0xbac0}, decode: Revsh16T1},
+        {Opcode: Opcode{mask: 0xfd00, value: 0xb100}, decode: CompareBranchZero16},
+        {Opcode: Opcode{mask: 0xfd00, value: 0xb900}, decode: CompareBranchNonZero16},
+        {Opcode: Opcode{mask: 0xff00, value: 0xbf00}, table: it_hints16},
+    },
+}
+
+/* If-Then, and hints
+ * ARMv7-M ARM A5.2.5 */
+var it_hints16 = &DecodeTable{
+    name: "If-Then, and hints",
+    key:  0x00ff,
+    entries: []DecodeEntry{
+        /* IT for any mask other than 0000 */
+        {Opcode: Opcode{mask: 0xff01, value: 0xbf01}, decode: IfThen16},
+        {Opcode: Opcode{mask: 0xff03, value: 0xbf02}, decode: IfThen16},
+        {Opcode: Opcode{mask: 0xff07, value: 0xbf04}, decode: IfThen16},
+        {Opcode: Opcode{mask: 0xff0f, value: 0xbf08}, decode: IfThen16},
+    },
+}
+
+/* Conditional branch, and Supervisor Call
+ * ARMv7-M ARM A5.2.6 */
+var cond_branch_svc16 = &DecodeTable{
+    name: "Conditional branch, and Supervisor Call",
+    key:  0x0f00,
+    entries: []DecodeEntry{
+        /* B T1 for any condition other than 111x */
+        {Opcode: Opcode{mask: 0xf800, value: 0xd000}, decode: Branch16T1},
+        {Opcode: Opcode{mask: 0xfc00, value: 0xd800}, decode: Branch16T1},
+        {Opcode: Opcode{mask: 0xfe00, value: 0xdc00}, decode: Branch16T1},
+    },
+}
+
+/* 32-bit Thumb instruction encoding
+ * ARMv7-M ARM A5.3
+ *
+ * The first halfword is in bits [31:16], so op1 is bits [28:27],
+ * op2 is bits [26:20] and op is bit 15. */
+var InstrOpcodes32 = &DecodeTable{
+    name: "32-bit Thumb instruction encoding",
+    key:  0x1ff08000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0x1e400000, value: 0x08000000}, table: load_store_multiple32},
+        {Opcode: Opcode{mask: 0x1e400000, value: 0x08400000}, table: load_store_dual_exclusive32},
+        {Opcode: Opcode{mask: 0x1e000000, value: 0x0a000000}, table: data_processing_shifted_reg32},
+        {Opcode: Opcode{mask: 0x1a008000, value: 0x10000000}, table: data_processing_mod_imm32},
+        {Opcode: Opcode{mask: 0x1a008000, value: 0x12000000}, table: data_processing_plain_imm32},
+        {Opcode: Opcode{mask: 0x18008000, value: 0x10008000}, table: branch_misc_control32},
+        {Opcode: Opcode{mask: 0x1f100000, value: 0x18000000}, table: store_single32},
+        {Opcode: Opcode{mask: 0x1e700000, value: 0x18100000}, table: load_byte32},
+        {Opcode: Opcode{mask: 0x1e700000, value: 0x18300000}, table: load_halfword32},
+        {Opcode: Opcode{mask: 0x1e700000, value: 0x18500000}, table: load_word32},
+        {Opcode: Opcode{mask: 0x1f000000, value: 0x1a000000}, table: data_processing_reg32},
+        {Opcode: Opcode{mask: 0x1f800000, value: 0x1b000000}, table: multiply32},
+        {Opcode: Opcode{mask: 0x1f800000, value: 0x1b800000}, table: long_multiply_divide32},
+    },
+}
+
+/* Data processing (modified immediate)
+ * ARMv7-M ARM A5.3.1 */
+var data_processing_mod_imm32 = &DecodeTable{
+    name: "Data processing (modified immediate)",
+    key:  0x01f00000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf0000000}, decode: AndImm32T1}, // TST when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf0200000}, decode: BicImm32T1},
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf0400000}, decode: OrrImm32T1}, // MOV when Rn is PC
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf0600000}, decode: OrnImm32T1}, // MVN when Rn is PC
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf0800000}, decode: EorImm32T1}, // TEQ when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf1000000}, decode: AddImm32T3}, // CMN when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf1400000}, decode: AdcImm32T1},
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf1600000}, decode: SbcImm32T1},
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf1a00000}, decode: SubImm32T3}, // CMP when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xfbe08000, value: 0xf1c00000}, decode: RsbImm32T2},
+    },
+}
+
+/* Data processing (plain binary immediate)
+ * ARMv7-M ARM A5.3.3 */
+var data_processing_plain_imm32 = &DecodeTable{
+    name: "Data processing (plain binary immediate)",
+    key:  0x01f00000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfbf08000, value: 0xf2000000}, decode: AddImm32T4}, // ADR when Rn is PC
+        {Opcode: Opcode{mask: 0xfbf08000, value: 0xf2400000}, decode: MovImm32T3},
+        {Opcode: Opcode{mask: 0xfbf08000, value: 0xf2a00000}, decode: SubImm32T4}, // ADR when Rn is PC
+        {Opcode: Opcode{mask: 0xfbf08000, value: 0xf2c00000}, decode: MovTop32T1},
+        {Opcode: Opcode{mask: 0xffd08000, value: 0xf3000000}, decode: Ssat32T1},
+        {Opcode: Opcode{mask: 0xfff08000, value: 0xf3400000}, decode: Sbfx32T1},
+        {Opcode: Opcode{mask: 0xfff08000, value: 0xf3600000}, decode: Bfi32T1}, // BFC when Rn is PC
+        {Opcode: Opcode{mask: 0xffd08000, value: 0xf3800000}, decode: Usat32T1},
+        {Opcode: Opcode{mask: 0xfff08000, value: 0xf3c00000}, decode: Ubfx32T1},
+    },
+}
+
+/* Branches and miscellaneous control
+ * ARMv7-M ARM A5.3.4 */
+var branch_misc_control32 = &DecodeTable{
+    name: "Branches and miscellaneous control",
+    key:  0x07f05000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xf800d000, value: 0xf000d000}, decode: BranchLink32T1},
+        {Opcode: Opcode{mask: 0xfff0d0f0, value: 0xf3b08020}, decode: Clrex32T1},
+    },
+}
+
+/* Load Multiple and Store Multiple
+ * ARMv7-M ARM A5.3.5 */
+var load_store_multiple32 = &DecodeTable{
+    name: "Load Multiple and Store Multiple",
+    key:  0x01900000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xffd00000, value: 0xe8800000}, decode: Stm32T2},
+        {Opcode: Opcode{mask: 0xffd00000, value: 0xe8900000}, decode: Ldm32T2},   // POP when Rn is SP and W is set
+        {Opcode: Opcode{mask: 0xffd00000, value: 0xe9000000}, decode: Stmdb32T1}, // PUSH when Rn is SP and W is set
+        {Opcode: Opcode{mask: 0xffd00000, value: 0xe9100000}, decode: Ldmdb32T1},
+    },
+}
+
+/* Load/store dual or exclusive, table branch
+ * ARMv7-M ARM A5.3.6 */
+var load_store_dual_exclusive32 = &DecodeTable{
+    name: "Load/store dual or exclusive, table branch",
+    key:  0x01b000f0,
+    entries: []DecodeEntry{
+        /* LDRD and STRD for any P, W other than 00 */
+        {Opcode: Opcode{mask: 0xff700000, value: 0xe8600000}, decode: StrdImm32T1},
+        {Opcode: Opcode{mask: 0xff500000, value: 0xe9400000}, decode: StrdImm32T1},
+        {Opcode: Opcode{mask: 0xff700000, value: 0xe8700000}, decode: LdrdImm32T1}, // LDRD (literal) when Rn is PC
+        {Opcode: Opcode{mask: 0xff500000, value: 0xe9500000}, decode: LdrdImm32T1}, // LDRD (literal) when Rn is PC
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xe8400000}, decode: Strex32T1},
+        {Opcode: Opcode{mask: 0xfff00f00, value: 0xe8500f00}, decode: Ldrex32T1},
+        {Opcode: Opcode{mask: 0xfff00ff0, value: 0xe8c00f40}, decode: Strexb32T1},
+        {Opcode: Opcode{mask: 0xfff00ff0, value: 0xe8c00f50}, decode: Strexh32T1},
+        {Opcode: Opcode{mask: 0xfff00fff, value: 0xe8d00f4f}, decode: Ldrexb32T1},
+        {Opcode: Opcode{mask: 0xfff00fff, value: 0xe8d00f5f}, decode: Ldrexh32T1},
+        {Opcode: Opcode{mask: 0xfff0fff0, value: 0xe8d0f000}, decode: TableBranchByte32},
+        {Opcode: Opcode{mask: 0xfff0fff0, value: 0xe8d0f010}, decode: TableBranchHalfword32},
+    },
+}
+
+/* Load word
+ * ARMv7-M ARM A5.3.7 */
+var load_word32 = &DecodeTable{
+    name: "Load word",
+    key:  0x01800fc0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8d00000}, decode: LdrImm32T3}, // LDR (literal) when Rn is PC
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8500800}, decode: LdrImm32T4}, // LDR (literal) when Rn is PC
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8500000}, decode: LdrReg32T2}, // LDR (literal) when Rn is PC
+    },
+}
+
+/* Load halfword, memory hints
+ * ARMv7-M ARM A5.3.8 */
+var load_halfword32 = &DecodeTable{
+    name: "Load halfword, memory hints",
+    key:  0x01800fc0,
+    entries: []DecodeEntry{
+        /* Literal loads when Rn is PC, and memory hints when Rt is PC */
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8b00000}, decode: LdrhImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8300800}, decode: LdrhImm32T3},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8300000}, decode: LdrhReg32T2},
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf9b00000}, decode: LdrshImm32T1},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf9300800}, decode: LdrshImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf9300000}, decode: LdrshReg32T2},
+    },
+}
+
+/* Load byte, memory hints
+ * ARMv7-M ARM A5.3.9 */
+var load_byte32 = &DecodeTable{
+    name: "Load byte, memory hints",
+    key:  0x01800fc0,
+    entries: []DecodeEntry{
+        /* Literal loads when Rn is PC, and memory hints when Rt is PC */
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8900000}, decode: LdrbImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8100800}, decode: LdrbImm32T3},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8100000}, decode: LdrbReg32T2},
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf9900000}, decode: LdrsbImm32T1},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf9100800}, decode: LdrsbImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf9100000}, decode: LdrsbReg32T2},
+    },
+}
+
+/* Store single data item
+ * ARMv7-M ARM A5.3.10 */
+var store_single32 = &DecodeTable{
+    name: "Store single data item",
+    key:  0x00e00800,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8800000}, decode: StrbImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8000800}, decode: StrbImm32T3},
+        {Opcode: Opcode{mask: 0xfff00fc0, value: 0xf8000000}, decode: StrbReg32T2},
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8a00000}, decode: StrhImm32T2},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8200800}, decode: StrhImm32T3},
+        {Opcode: Opcode{mask: 0xfff00fc0, value: 0xf8200000}, decode: StrhReg32T2},
+        {Opcode: Opcode{mask: 0xfff00000, value: 0xf8c00000}, decode: StrImm32T3},
+        {Opcode: Opcode{mask: 0xfff00800, value: 0xf8400800}, decode: StrImm32T4},
+        {Opcode: Opcode{mask: 0xfff00fc0, value: 0xf8400000}, decode: StrReg32T2},
+    },
+}
+
+/* Data processing (shifted register)
+ * ARMv7-M ARM A5.3.11 */
+var data_processing_shifted_reg32 = &DecodeTable{
+    name: "Data processing (shifted register)",
+    key:  0x01e00000,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xea000000}, decode: AndReg32T2}, // TST when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xea200000}, decode: BicReg32T2},
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xea400000}, decode: OrrReg32T2}, // MOV and immediate shifts when Rn is PC
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xea600000}, decode: OrnReg32T1}, // MVN when Rn is PC
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xea800000}, decode: EorReg32T2}, // TEQ when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xfff08010, value: 0xeac00000}, decode: Pkh32T1},
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xeb000000}, decode: AddReg32T3}, // CMN when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xeb400000}, decode: AdcReg32T2},
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xeb600000}, decode: SbcReg32T2},
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xeba00000}, decode: SubReg32T2}, // CMP when Rd is PC and S is set
+        {Opcode: Opcode{mask: 0xffe08000, value: 0xebc00000}, decode: RsbReg32T1},
+    },
+}
+
+/* Data processing (register)
+ * ARMv7-M ARM A5.3.12 */
+var data_processing_reg32 = &DecodeTable{
+    name: "Data processing (register)",
+    key:  0x00f000f0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfff0f0c0, value: 0xfa00f080}, decode: Sxtah32T1}, // SXTH when Rn is PC
+        {Opcode: Opcode{mask: 0xfff0f0c0, value: 0xfa10f080}, decode: Uxtah32T1}, // UXTH when Rn is PC
+        {Opcode: Opcode{mask: 0xfff0f0c0, value: 0xfa40f080}, decode: Sxtab32T1}, // SXTB when Rn is PC
+        {Opcode: Opcode{mask: 0xfff0f0c0, value: 0xfa50f080}, decode: Uxtab32T1}, // UXTB when Rn is PC
+        {Opcode: Opcode{mask: 0xfff0f0f0, value: 0xfa90f080}, decode: Rev32T2},
+        {Opcode: Opcode{mask: 0xfff0f0f0, value: 0xfa90f090}, decode: RevHalfwords32T2},
+        {Opcode: Opcode{mask: 0xfff0f0f0, value: 0xfa90f0a0}, decode: Rbit32T1},
+        {Opcode: Opcode{mask: 0xfff0f0f0, value: 0xfa90f0b0}, decode: Revsh32T2},
+        {Opcode: Opcode{mask: 0xfff0f0f0, value: 0xfab0f080}, decode: Clz32T1},
+    },
+}
+
+/* Multiply, multiply accumulate, and absolute difference
+ * ARMv7-M ARM A5.3.16 */
+var multiply32 = &DecodeTable{
+    name: "Multiply, multiply accumulate, and absolute difference",
+    key:  0x00700030,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfb000000}, decode: Mla32T1}, // MUL when Ra is PC
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfb000010}, decode: Mls32T1},
+    },
+}
+
+/* Long multiply, long multiply accumulate, and divide
+ * ARMv7-M ARM A5.3.17 */
+var long_multiply_divide32 = &DecodeTable{
+    name: "Long multiply, long multiply accumulate, and divide",
+    key:  0x007000f0,
+    entries: []DecodeEntry{
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfb800000}, decode: Smull32T1},
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfb9000f0}, decode: Sdiv32T1},
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfba00000}, decode: Umull32T1},
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfbb000f0}, decode: Udiv32T1},
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfbc00000}, decode: Smlal32T1},
+        {Opcode: Opcode{mask: 0xfff000f0, value: 0xfbe00000}, decode: Umlal32T1},
+    },
+}
+
+func init() {
+    if err := InstrOpcodes16.Build(); err != nil {
+        panic(err)
+    }
+
+    if err := InstrOpcodes32.Build(); err != nil {
+        panic(err)
+    }
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestDecodeTableOverlap(t *testing.T) {
+    table := &DecodeTable{
+        name: "overlapping",
+        key:  0xf800,
+        entries: []DecodeEntry{
+            {Opcode: Opcode{mask: 0xf800, value: 0x0000}, decode: LslImm16},
+            {Opcode: Opcode{mask: 0xffc0, value: 0x0000}, decode: MovReg16T2},
+            {Opcode: Opcode{mask: 0xf800, value: 0x2000}, decode: MovImm16},
+        },
+    }
+
+    err := table.Build()
+    if err == nil {
+        t.Fatalf("Overlapping entries not reported")
+    }
+
+    if !strings.Contains(err.Error(), "LslImm16") || !strings.Contains(err.Error(), "MovReg16T2") ||
+        strings.Contains(err.Error(), "MovImm16") {
+        t.Errorf("err: %v", err)
+    }
+}
+
+func TestDecodeTableSubtable(t *testing.T) {
+    sub := &DecodeTable{
+        name: "sub",
+        key:  0x0300,
+        entries: []DecodeEntry{
+            {Opcode: Opcode{mask: 0xff00, value: 0x4100}, decode: MovImm16},
+        },
+    }
+
+    table := &DecodeTable{
+        name: "top",
+        key:  0xf000,
+        entries: []DecodeEntry{
+            {Opcode: Opcode{mask: 0xf000, value: 0x4000}, table: sub},
+            {Opcode: Opcode{mask: 0xf000, value: 0x5000}, table: sub},
+        },
+    }
+
+    if err := table.Build(); err != nil {
+        t.Fatalf("Build: %v", err)
+    }
+
+    cases := []struct {
+        instr FetchedInstr16
+        valid bool
+    }{
+        {instr: 0x4100, valid: true},
+        {instr: 0x5145, valid: false}, // Reaches the subtable, but does not match
+        {instr: 0x4200, valid: false},
+        {instr: 0x6100, valid: false},
+    }
+
+    for _, test := range cases {
+        instr, err := table.Decode(test.instr)
+        if test.valid && (err != nil || reflect.TypeOf(instr) != reflect.TypeOf(MovImm{})) {
+            t.Errorf("instr %v: %#v, %v", test.instr, instr, err)
+        } else if !test.valid && err != ErrUndefinedInstruction {
+            t.Errorf("instr %v: %#v, expected undefined", test.instr, instr)
+        }
+    }
+}
+
+/* Overlapping patterns must always resolve the same way */
+func TestDecodeDeterministic(t *testing.T) {
+    for i := 0; i < 100; i++ {
+        instr, err := FetchedInstr16(0x0000).Decode()
+        if err != nil || instr != (MovRegT2{Rd: 0, Rm: 0, Rn: 0, Imm: 0, setflags: ALWAYS}) {
+            t.Fatalf("0x0000 decoded as %#v, %v", instr, err)
+        }
+    }
+}
+
+/* Every 16-bit instruction decodes without panicking, and
+ * 32-bit prefixes are always recognised */
+func TestDecodeAll16(t *testing.T) {
+    for raw := 0; raw <= 0xffff; raw++ {
+        instr := FetchedInstr16(raw)
+        _, err := instr.Decode()
+
+        prefix := raw & WORD_INSTR_MASK
+        is_word := prefix == WORD_INSTR1 || prefix == WORD_INSTR2 || prefix == WORD_INSTR3
+
+        if is_word != (err == ErrIncompleteInstruction) {
+            t.Errorf("instr %v: %v", instr, err)
+        }
+    }
+}
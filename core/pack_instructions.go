@@ -0,0 +1,40 @@
+package core
+
+import "fmt"
+
+/* PKHBT, PKHTB - Pack Halfword
+ * ARM ARM A7.7.92
+ * Encoding T1
+ *
+ * PKHTB is the form with an ASR shift, taking the bottom halfword from the
+ * shifted Rm rather than from Rn. */
+type PkhT1 InstrFields
+
+func Pkh32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, Rm, shift, _ := shifted_reg_fields(instr.Uint32())
+
+    return PkhT1{Rd: Rd, Rm: Rm, Rn: Rn, Imm: 0, Shift: shift, setflags: NEVER}
+}
+
+func (instr PkhT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    operand2, _ := instr.Shift.EvaluateC(regs.R(instr.Rm), regs.Apsr.C)
+
+    if instr.Shift.srtype == SRTYPE_ASR {
+        regs.SetR(instr.Rd, (regs.R(instr.Rn)&0xffff0000)|(operand2&0xffff))
+    } else {
+        regs.SetR(instr.Rd, (operand2&0xffff0000)|(regs.R(instr.Rn)&0xffff))
+    }
+
+    return nil
+}
+
+func (instr PkhT1) String() string {
+    if instr.Shift.srtype == SRTYPE_ASR {
+        return fmt.Sprintf("pkhtb %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+    }
+    return fmt.Sprintf("pkhbt %s, %s, %s%s", instr.Rd, instr.Rn, instr.Rm, instr.Shift)
+}
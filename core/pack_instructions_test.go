@@ -0,0 +1,51 @@
+package core
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyPkh(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xeac14002), instr_valid: true},  // pkhbt r0, r1, r2, lsl #16
+        {instr: FetchedInstr32(0xeac44325), instr_valid: true},  // pkhtb r3, r4, r5, asr #16
+        {instr: FetchedInstr32(0xead14002), instr_valid: false}, // pkhbt with S set
+        {instr: FetchedInstr32(0xeb0100c2), instr_valid: false}, // add.w r0, r1, r2, lsl #3
+    }
+
+    test_identify(t, cases, reflect.TypeOf(PkhT1{}))
+}
+
+func TestDecodePkh32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // pkhbt r0, r1, r2, lsl #16
+        {instr: FetchedInstr32(0xeac14002), decoded: PkhT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 16}, setflags: NEVER}},
+        // pkhtb r3, r4, r5, asr #32
+        {instr: FetchedInstr32(0xeac40325), decoded: PkhT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 32}, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Pkh32T1)
+}
+
+func TestExecutePkh(t *testing.T) {
+    cases := []ExecuteCase{
+        // pkhbt r0, r1, r2, lsl #16
+        {instr: PkhT1{Rd: 0, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 16}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x1111aaaa, 0x2222bbbb, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xbbbbaaaa, 0x1111aaaa, 0x2222bbbb, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // pkhtb r3, r4, r5, asr #16
+        {instr: PkhT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 16}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x1111aaaa, 0x2222bbbb, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x11112222, 0x1111aaaa, 0x2222bbbb, 6, 7, 8, 9, 10, 11, 12}}},
+        // pkhtb r3, r4, r5, asr #32
+        {instr: PkhT1{Rd: 3, Rm: 5, Rn: 4, Imm: 0, Shift: Shift{srtype: SRTYPE_ASR, amount: 32}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 0x1111aaaa, 0x80000000, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 0x1111ffff, 0x1111aaaa, 0x80000000, 6, 7, 8, 9, 10, 11, 12}}},
+        // pkhbt sp, r1, r2 (UNPREDICTABLE)
+        {instr: PkhT1{Rd: SP, Rm: 2, Rn: 1, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
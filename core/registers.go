@@ -61,6 +61,9 @@ type Registers struct {
     Faultmask bool
     Basepri   uint8
     Control   Control
+    branched  bool    // PC written by the current instruction
+    exc_ret   bool    // EXC_RETURN value written to PC by an interworking branch or load
+    monitor   Monitor // Local exclusive monitor
 }
 
 /* Special registers in r13-15 */
@@ -126,6 +129,37 @@ func (regs Registers) Pc() uint32 {
     return regs.R(PC)
 }
 
+/* Combined program status register, as stacked on exception entry
+ * ARM ARM B1.4.2 */
+func (regs Registers) Xpsr() uint32 {
+    var xpsr uint32
+
+    xpsr |= uint32(booltou(regs.Apsr.N)) << 31
+    xpsr |= uint32(booltou(regs.Apsr.Z)) << 30
+    xpsr |= uint32(booltou(regs.Apsr.C)) << 29
+    xpsr |= uint32(booltou(regs.Apsr.V)) << 28
+    xpsr |= uint32(booltou(regs.Apsr.Q)) << 27
+    xpsr |= uint32(regs.Epsr.IT&0x3) << 25
+    xpsr |= uint32(booltou(regs.Epsr.T)) << 24
+    xpsr |= uint32(regs.Apsr.GE&0xf) << 16
+    xpsr |= uint32(regs.Epsr.IT>>2) << 10
+    xpsr |= uint32(regs.Ipsr.ExcpNum & 0x1ff)
+
+    return xpsr
+}
+
+func (regs *Registers) SetXpsr(xpsr uint32) {
+    regs.Apsr.N = (xpsr & (1 << 31)) != 0
+    regs.Apsr.Z = (xpsr & (1 << 30)) != 0
+    regs.Apsr.C = (xpsr & (1 << 29)) != 0
+    regs.Apsr.V = (xpsr & (1 << 28)) != 0
+    regs.Apsr.Q = (xpsr & (1 << 27)) != 0
+    regs.Apsr.GE = uint8((xpsr >> 16) & 0xf)
+    regs.Epsr.T = (xpsr & (1 << 24)) != 0
+    regs.Epsr.IT = uint16(((xpsr>>10)&0x3f)<<2 | (xpsr>>25)&0x3)
+    regs.Ipsr.ExcpNum = uint16(xpsr & 0x1ff)
+}
+
 func (regs Registers) InITBlock() bool {
     return (regs.Epsr.IT & 0xf) != 0
 }
@@ -134,8 +168,29 @@ func (regs Registers) LastInITBlock() bool {
     return (regs.Epsr.IT & 0xf) == 0x8
 }
 
+/* Condition for the current instruction, from ITSTATE if in an IT block
+ * ARM ARM A7.3.1 */
+func (regs Registers) CurrentCond() Condition {
+    if regs.InITBlock() {
+        return Condition((regs.Epsr.IT >> 4) & 0xf)
+    }
+
+    return COND_AL
+}
+
+/* Move ITSTATE on to the next instruction in the IT block
+ * ARM ARM A7.3.2 */
+func (regs *Registers) ITAdvance() {
+    if (regs.Epsr.IT & 0x7) == 0 {
+        regs.Epsr.IT = 0
+    } else {
+        regs.Epsr.IT = (regs.Epsr.IT & 0xe0) | ((regs.Epsr.IT << 1) & 0x1f)
+    }
+}
+
 func (regs *Registers) BranchTo(addr uint32) {
     regs.SetR(PC, addr)
+    regs.branched = true
 }
 
 func (regs *Registers) BranchWritePC(addr uint32) {
@@ -148,6 +203,30 @@ func (regs *Registers) ALUWritePC(addr uint32) {
     regs.BranchWritePC(addr)
 }
 
+/* Branch, selecting the instruction set from bit 0 of addr.  In Handler
+ * mode, an address in the top 256MB is an EXC_RETURN value, which the CPU
+ * completes once the instruction finishes.
+ * ARM ARM A2.3.1 */
+func (regs *Registers) BXWritePC(addr uint32) {
+    if regs.Mode == MODE_HANDLER && (addr>>28) == 0xf {
+        regs.BranchTo(addr)
+        regs.exc_ret = true
+        return
+    }
+
+    regs.Epsr.T = (addr & 0x1) != 0
+    regs.BranchTo(addr &^ 0x1)
+}
+
+func (regs *Registers) LoadWritePC(addr uint32) {
+    regs.BXWritePC(addr)
+}
+
+func (regs *Registers) BLXWritePC(addr uint32) {
+    regs.Epsr.T = (addr & 0x1) != 0
+    regs.BranchTo(addr &^ 0x1)
+}
+
 func (regs Registers) Pretty() string {
     var b bytes.Buffer
     var i RegIndex
@@ -0,0 +1,202 @@
+package core
+
+import "fmt"
+
+/* Fields shared by the 32-bit reverse and count leading zeros encodings,
+ * which encode Rm twice.  The copy in the Rn field is kept in Rn, and the
+ * instruction is UNPREDICTABLE if the two differ. */
+func reverse_fields(raw_instr uint32) (Rd RegIndex, Rm RegIndex, Rm2 RegIndex) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rm = RegIndex(raw_instr & 0xf)
+    Rm2 = RegIndex((raw_instr >> 16) & 0xf)
+
+    return Rd, Rm, Rm2
+}
+
+/* REV - Byte-Reverse Word
+ * ARM ARM A7.7.111
+ * Encoding T1 */
+type RevT1 InstrFields
+
+func Rev16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return RevT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevT1) Execute(regs *Registers, mem Memory) error {
+    regs.SetR(instr.Rd, ReverseBytes(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevT1) String() string {
+    return fmt.Sprintf("rev %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV - Byte-Reverse Word
+ * ARM ARM A7.7.111
+ * Encoding T2 */
+type RevT2 InstrFields
+
+func Rev32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rm, Rm2 := reverse_fields(instr.Uint32())
+
+    return RevT2{Rd: Rd, Rm: Rm, Rn: Rm2, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn != instr.Rm || bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, ReverseBytes(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevT2) String() string {
+    return fmt.Sprintf("rev.w %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV16 - Byte-Reverse Packed Halfword
+ * ARM ARM A7.7.112
+ * Encoding T1 */
+type RevHalfwordsT1 InstrFields
+
+func RevHalfwords16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return RevHalfwordsT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevHalfwordsT1) Execute(regs *Registers, mem Memory) error {
+    regs.SetR(instr.Rd, ReverseHalfwordBytes(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevHalfwordsT1) String() string {
+    return fmt.Sprintf("rev16 %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REV16 - Byte-Reverse Packed Halfword
+ * ARM ARM A7.7.112
+ * Encoding T2 */
+type RevHalfwordsT2 InstrFields
+
+func RevHalfwords32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rm, Rm2 := reverse_fields(instr.Uint32())
+
+    return RevHalfwordsT2{Rd: Rd, Rm: Rm, Rn: Rm2, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevHalfwordsT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn != instr.Rm || bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, ReverseHalfwordBytes(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevHalfwordsT2) String() string {
+    return fmt.Sprintf("rev16.w %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REVSH - Byte-Reverse Signed Halfword
+ * ARM ARM A7.7.113
+ * Encoding T1 */
+type RevshT1 InstrFields
+
+func Revsh16T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm := extend_fields16(instr.Uint32())
+
+    return RevshT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevshT1) Execute(regs *Registers, mem Memory) error {
+    regs.SetR(instr.Rd, ReverseSignedHalfword(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevshT1) String() string {
+    return fmt.Sprintf("revsh %s, %s", instr.Rd, instr.Rm)
+}
+
+/* REVSH - Byte-Reverse Signed Halfword
+ * ARM ARM A7.7.113
+ * Encoding T2 */
+type RevshT2 InstrFields
+
+func Revsh32T2(instr FetchedInstr) DecodedInstr {
+    Rd, Rm, Rm2 := reverse_fields(instr.Uint32())
+
+    return RevshT2{Rd: Rd, Rm: Rm, Rn: Rm2, Imm: 0, setflags: NEVER}
+}
+
+func (instr RevshT2) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn != instr.Rm || bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, ReverseSignedHalfword(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RevshT2) String() string {
+    return fmt.Sprintf("revsh.w %s, %s", instr.Rd, instr.Rm)
+}
+
+/* RBIT - Reverse Bits
+ * ARM ARM A7.7.110
+ * Encoding T1 */
+type RbitT1 InstrFields
+
+func Rbit32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm, Rm2 := reverse_fields(instr.Uint32())
+
+    return RbitT1{Rd: Rd, Rm: Rm, Rn: Rm2, Imm: 0, setflags: NEVER}
+}
+
+func (instr RbitT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn != instr.Rm || bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, ReverseBits(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr RbitT1) String() string {
+    return fmt.Sprintf("rbit %s, %s", instr.Rd, instr.Rm)
+}
+
+/* CLZ - Count Leading Zeros
+ * ARM ARM A7.7.24
+ * Encoding T1 */
+type ClzT1 InstrFields
+
+func Clz32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rm, Rm2 := reverse_fields(instr.Uint32())
+
+    return ClzT1{Rd: Rd, Rm: Rm, Rn: Rm2, Imm: 0, setflags: NEVER}
+}
+
+func (instr ClzT1) Execute(regs *Registers, mem Memory) error {
+    if instr.Rn != instr.Rm || bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    regs.SetR(instr.Rd, CountLeadingZeros(regs.R(instr.Rm)))
+
+    return nil
+}
+
+func (instr ClzT1) String() string {
+    return fmt.Sprintf("clz %s, %s", instr.Rd, instr.Rm)
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifyReverse(t *testing.T) {
+    cases := []struct {
+        instr      FetchedInstr
+        instr_type reflect.Type
+    }{
+        {instr: FetchedInstr16(0xba08), instr_type: reflect.TypeOf(RevT1{})},              // rev r0, r1
+        {instr: FetchedInstr16(0xba5a), instr_type: reflect.TypeOf(RevHalfwordsT1{})},     // rev16 r2, r3
+        {instr: FetchedInstr16(0xbaec), instr_type: reflect.TypeOf(RevshT1{})},            // revsh r4, r5
+        {instr: FetchedInstr32(0xfa95f485), instr_type: reflect.TypeOf(RevT2{})},          // rev.w r4, r5
+        {instr: FetchedInstr32(0xfa97f697), instr_type: reflect.TypeOf(RevHalfwordsT2{})}, // rev16.w r6, r7
+        {instr: FetchedInstr32(0xfa99f8b9), instr_type: reflect.TypeOf(RevshT2{})},        // revsh.w r8, r9
+        {instr: FetchedInstr32(0xfa93f2a3), instr_type: reflect.TypeOf(RbitT1{})},         // rbit r2, r3
+        {instr: FetchedInstr32(0xfab1f081), instr_type: reflect.TypeOf(ClzT1{})},          // clz r0, r1
+    }
+
+    for _, test := range cases {
+        test_identify(t, []IdentifyCase{{instr: test.instr, instr_valid: true}}, test.instr_type)
+    }
+}
+
+func TestDecodeClz32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // clz r0, r1
+        {instr: FetchedInstr32(0xfab1f081), decoded: ClzT1{Rd: 0, Rm: 1, Rn: 1, Imm: 0, setflags: NEVER}},
+        // clz r0, r1, with r2 as the second copy of Rm
+        {instr: FetchedInstr32(0xfab2f081), decoded: ClzT1{Rd: 0, Rm: 1, Rn: 2, Imm: 0, setflags: NEVER}},
+    }
+
+    test_decode(t, cases, Clz32T1)
+}
+
+func TestExecuteReverse(t *testing.T) {
+    cases := []ExecuteCase{
+        // rev r0, r1
+        {instr: RevT1{Rd: 0, Rm: 1, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x78563412, 0x12345678, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // rev16 r2, r3
+        {instr: RevHalfwordsT1{Rd: 2, Rm: 3, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x12345678, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x34127856, 0x12345678, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // revsh r4, r5
+        {instr: RevshT1{Rd: 4, Rm: 5, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0x12345680, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xffff8056, 0x12345680, 6, 7, 8, 9, 10, 11, 12}}},
+        // rev.w r4, r5
+        {instr: RevT2{Rd: 4, Rm: 5, Rn: 5, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 0xaabbccdd, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0xddccbbaa, 0xaabbccdd, 6, 7, 8, 9, 10, 11, 12}}},
+        // revsh.w r8, r9
+        {instr: RevshT2{Rd: 8, Rm: 9, Rn: 9, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x00001234, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x3412, 0x00001234, 10, 11, 12}}},
+        // rbit r2, r3
+        {instr: RbitT1{Rd: 2, Rm: 3, Rn: 3, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x00000001, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x80000000, 0x00000001, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // clz r0, r1
+        {instr: ClzT1{Rd: 0, Rm: 1, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x00010000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{15, 0x00010000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // clz r0, r1, of zero
+        {instr: ClzT1{Rd: 0, Rm: 1, Rn: 1, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{32, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // clz r0, r1, with a different second copy of Rm (UNPREDICTABLE)
+        {instr: ClzT1{Rd: 0, Rm: 1, Rn: 2, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // rev.w sp, r5 (UNPREDICTABLE)
+        {instr: RevT2{Rd: SP, Rm: 5, Rn: 5, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringReverse(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr
+        expected string
+    }{
+        {instr: FetchedInstr16(0xba08), expected: "rev r0, r1"},
+        {instr: FetchedInstr16(0xba5a), expected: "rev16 r2, r3"},
+        {instr: FetchedInstr16(0xbaec), expected: "revsh r4, r5"},
+        {instr: FetchedInstr32(0xfa97f697), expected: "rev16.w r6, r7"},
+        {instr: FetchedInstr32(0xfa93f2a3), expected: "rbit r2, r3"},
+        {instr: FetchedInstr32(0xfab1f081), expected: "clz r0, r1"},
+    }
+
+    for _, test := range cases {
+        instr, err := test.instr.Decode()
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
@@ -0,0 +1,29 @@
+package core
+
+import "math/bits"
+
+/* Reverse the order of the bytes in value */
+func ReverseBytes(value uint32) uint32 {
+    return bits.ReverseBytes32(value)
+}
+
+/* Reverse the order of the bytes in each halfword of value */
+func ReverseHalfwordBytes(value uint32) uint32 {
+    return ((value & 0x00ff00ff) << 8) | ((value >> 8) & 0x00ff00ff)
+}
+
+/* Reverse the order of the bytes in the low halfword of value, sign
+ * extending the result */
+func ReverseSignedHalfword(value uint32) uint32 {
+    return SignExtend(uint32(bits.ReverseBytes16(uint16(value))), 2)
+}
+
+/* Reverse the order of the bits in value */
+func ReverseBits(value uint32) uint32 {
+    return bits.Reverse32(value)
+}
+
+/* Count the zero bits above the leftmost set bit of value */
+func CountLeadingZeros(value uint32) uint32 {
+    return uint32(bits.LeadingZeros32(value))
+}
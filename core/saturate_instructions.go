@@ -0,0 +1,73 @@
+package core
+
+import "fmt"
+
+/* Fields shared by the saturate encodings, with the shift decoded from
+ * sh:'0' and imm3:imm2 */
+func saturate_fields(raw_instr uint32) (Rd RegIndex, Rn RegIndex, shift Shift, sat_imm uint32) {
+    Rd = RegIndex((raw_instr >> 8) & 0xf)
+    Rn = RegIndex((raw_instr >> 16) & 0xf)
+    imm5 := ((raw_instr>>12)&0x7)<<2 | (raw_instr>>6)&0x3
+    shift = DecodeImmShift(((raw_instr>>21)&0x1)<<1, imm5)
+    sat_imm = raw_instr & 0x1f
+
+    return Rd, Rn, shift, sat_imm
+}
+
+/* SSAT - Signed Saturate
+ * ARM ARM A7.7.150 */
+type SsatT1 InstrFields
+
+func Ssat32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, shift, sat_imm := saturate_fields(instr.Uint32())
+
+    if shift.srtype == SRTYPE_ASR && shift.amount == 32 {
+        /* SSAT16, which is only in ARMv7E-M */
+        return UndefinedInstr{}
+    }
+
+    return SsatT1{Rd: Rd, Rn: Rn, Imm: sat_imm + 1, Shift: shift, setflags: NEVER}
+}
+
+func (instr SsatT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Saturate(regs, InstrFields(instr), true)
+
+    return nil
+}
+
+func (instr SsatT1) String() string {
+    return fmt.Sprintf("ssat %s, #%d, %s%s", instr.Rd, instr.Imm, instr.Rn, instr.Shift)
+}
+
+/* USAT - Unsigned Saturate
+ * ARM ARM A7.7.213 */
+type UsatT1 InstrFields
+
+func Usat32T1(instr FetchedInstr) DecodedInstr {
+    Rd, Rn, shift, sat_imm := saturate_fields(instr.Uint32())
+
+    if shift.srtype == SRTYPE_ASR && shift.amount == 32 {
+        /* USAT16, which is only in ARMv7E-M */
+        return UndefinedInstr{}
+    }
+
+    return UsatT1{Rd: Rd, Rn: Rn, Imm: sat_imm, Shift: shift, setflags: NEVER}
+}
+
+func (instr UsatT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rn) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    Saturate(regs, InstrFields(instr), false)
+
+    return nil
+}
+
+func (instr UsatT1) String() string {
+    return fmt.Sprintf("usat %s, #%d, %s%s", instr.Rd, instr.Imm, instr.Rn, instr.Shift)
+}
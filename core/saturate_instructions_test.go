@@ -0,0 +1,135 @@
+package core
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+func TestIdentifySsat(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf3011007), instr_valid: true},  // ssat r0, #8, r1, lsl #4
+        {instr: FetchedInstr32(0xf3210007), instr_valid: false}, // ssat16 r0, #8, r1
+        {instr: FetchedInstr32(0xf3a3029f), instr_valid: false}, // usat r2, #31, r3, asr #2
+    }
+
+    test_identify(t, cases, reflect.TypeOf(SsatT1{}))
+}
+
+func TestDecodeSsat32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // ssat r0, #8, r1, lsl #4
+        {instr: FetchedInstr32(0xf3011007), decoded: SsatT1{Rd: 0, Rm: 0, Rn: 1, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 4}, setflags: NEVER}},
+        // ssat r7, #32, r8, asr #31
+        {instr: FetchedInstr32(0xf32877df), decoded: SsatT1{Rd: 7, Rm: 0, Rn: 8, Imm: 32, Shift: Shift{srtype: SRTYPE_ASR, amount: 31}, setflags: NEVER}},
+        // ssat16 r0, #8, r1 (UNDEFINED)
+        {instr: FetchedInstr32(0xf3210007), decoded: UndefinedInstr{}},
+    }
+
+    test_decode(t, cases, Ssat32T1)
+}
+
+func TestExecuteSsat(t *testing.T) {
+    cases := []ExecuteCase{
+        // ssat r0, #8, r1, lsl #4
+        {instr: SsatT1{Rd: 0, Rm: 0, Rn: 1, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 4}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x7, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x70, 0x7, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ssat r0, #8, r1, lsl #4, saturating high
+        {instr: SsatT1{Rd: 0, Rm: 0, Rn: 1, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 4}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x8, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0x7f, 0x8, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // ssat r0, #1, r1, saturating low
+        {instr: SsatT1{Rd: 0, Rm: 0, Rn: 1, Imm: 1, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0xfffffffe, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0xffffffff, 0xfffffffe, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // ssat r7, #32, r8, asr #31, which never saturates and leaves Q set
+        {instr: SsatT1{Rd: 7, Rm: 0, Rn: 8, Imm: 32, Shift: Shift{srtype: SRTYPE_ASR, amount: 31}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0x80000000, 9, 10, 11, 12}, Apsr: Apsr{Q: true}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0xffffffff, 0x80000000, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // ssat sp, #8, r1 (UNPREDICTABLE)
+        {instr: SsatT1{Rd: SP, Rm: 0, Rn: 1, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 0x1000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0x1000, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // ssat r0, #8, pc (UNPREDICTABLE)
+        {instr: SsatT1{Rd: 0, Rm: 0, Rn: PC, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyUsat(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr32(0xf3a3029f), instr_valid: true},  // usat r2, #31, r3, asr #2
+        {instr: FetchedInstr32(0xf3820100), instr_valid: true},  // usat r1, #0, r2
+        {instr: FetchedInstr32(0xf3a10008), instr_valid: false}, // usat16 r0, #8, r1
+        {instr: FetchedInstr32(0xf3011007), instr_valid: false}, // ssat r0, #8, r1, lsl #4
+    }
+
+    test_identify(t, cases, reflect.TypeOf(UsatT1{}))
+}
+
+func TestDecodeUsat32T1(t *testing.T) {
+    cases := []DecodeCase{
+        // usat r2, #31, r3, asr #2
+        {instr: FetchedInstr32(0xf3a3029f), decoded: UsatT1{Rd: 2, Rm: 0, Rn: 3, Imm: 31, Shift: Shift{srtype: SRTYPE_ASR, amount: 2}, setflags: NEVER}},
+        // usat r1, #0, r2
+        {instr: FetchedInstr32(0xf3820100), decoded: UsatT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER}},
+        // usat16 r0, #8, r1 (UNDEFINED)
+        {instr: FetchedInstr32(0xf3a10008), decoded: UndefinedInstr{}},
+    }
+
+    test_decode(t, cases, Usat32T1)
+}
+
+func TestExecuteUsat(t *testing.T) {
+    cases := []ExecuteCase{
+        // usat r2, #31, r3, asr #2
+        {instr: UsatT1{Rd: 2, Rm: 0, Rn: 3, Imm: 31, Shift: Shift{srtype: SRTYPE_ASR, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0x7ffffffc, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x1fffffff, 0x7ffffffc, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+        // usat r2, #31, r3, asr #2, saturating low
+        {instr: UsatT1{Rd: 2, Rm: 0, Rn: 3, Imm: 31, Shift: Shift{srtype: SRTYPE_ASR, amount: 2}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 0xfffffffc, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0, 0xfffffffc, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // usat r1, #0, r2, saturating high
+        {instr: UsatT1{Rd: 1, Rm: 0, Rn: 2, Imm: 0, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // usat r1, #8, r2
+        {instr: UsatT1{Rd: 1, Rm: 0, Rn: 2, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 0x100, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 0xff, 0x100, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{Q: true}}},
+        // usat pc, #8, r2 (UNPREDICTABLE)
+        {instr: UsatT1{Rd: PC, Rm: 0, Rn: 2, Imm: 8, Shift: Shift{srtype: SRTYPE_LSL, amount: 0}, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 0x100, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 0x100, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestStringSaturate(t *testing.T) {
+    cases := []struct {
+        instr    FetchedInstr32
+        expected string
+    }{
+        {instr: 0xf3011007, expected: "ssat r0, #8, r1, lsl #4"},
+        {instr: 0xf3a3029f, expected: "usat r2, #31, r3, asr #2"},
+        {instr: 0xf3820100, expected: "usat r1, #0, r2"},
+    }
+
+    for _, test := range cases {
+        instr, err := InstrOpcodes32.Decode(test.instr)
+        if err != nil {
+            t.Errorf("%#x: %v", test.instr, err)
+            continue
+        }
+
+        if actual := instr.(fmt.Stringer).String(); actual != test.expected {
+            t.Errorf("%#x: %q, expected %q", test.instr, actual, test.expected)
+        }
+    }
+}
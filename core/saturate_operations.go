@@ -0,0 +1,48 @@
+package core
+
+/* Saturate i to a signed integer of n bits, returning whether it saturated
+ * ARM ARM A2.2.1 */
+func SignedSatQ(i int64, n uint32) (uint32, bool) {
+    max := int64(1)<<(n-1) - 1
+    min := -(int64(1) << (n - 1))
+
+    if i > max {
+        return uint32(max), true
+    } else if i < min {
+        return uint32(min), true
+    }
+    return uint32(i), false
+}
+
+/* Saturate i to an unsigned integer of n bits, returning whether it saturated
+ * ARM ARM A2.2.1 */
+func UnsignedSatQ(i int64, n uint32) (uint32, bool) {
+    max := int64(1)<<n - 1
+
+    if i > max {
+        return uint32(max), true
+    } else if i < 0 {
+        return 0, true
+    }
+    return uint32(i), false
+}
+
+/* Perform saturate instruction, with the bit position to saturate to in Imm,
+ * setting the Q flag if the shifted operand saturated */
+func Saturate(regs *Registers, instr InstrFields, signed bool) {
+    operand, _ := instr.Shift.EvaluateC(regs.R(instr.Rn), regs.Apsr.C)
+
+    var result uint32
+    var saturated bool
+
+    if signed {
+        result, saturated = SignedSatQ(int64(int32(operand)), instr.Imm)
+    } else {
+        result, saturated = UnsignedSatQ(int64(int32(operand)), instr.Imm)
+    }
+
+    regs.SetR(instr.Rd, result)
+    if saturated {
+        regs.Apsr.Q = true
+    }
+}
@@ -0,0 +1,211 @@
+package core
+
+/* System Control Space, private to the processor
+ * ARMv7-M ARM B3.2 */
+const (
+    SCS_BASE = 0xe000e000
+    SCS_SIZE = 0x1000
+)
+
+/* System Control Block register offsets within the SCS
+ * ARMv7-M ARM B3.2.2 */
+const (
+    SCS_CPUID = 0xd00
+    SCS_VTOR  = 0xd08
+    SCS_CCR   = 0xd14
+    SCS_SHCSR = 0xd24
+    SCS_CFSR  = 0xd28
+    SCS_HFSR  = 0xd2c
+    SCS_BFAR  = 0xd38
+)
+
+/* Cortex-M3 r2p1 */
+const CPUID = 0x412fc231
+
+/* Configuration and Control Register bits
+ * ARMv7-M ARM B3.2.8 */
+const (
+    CCR_NONBASETHRDENA = 1 << 0
+    CCR_UNALIGN_TRP    = 1 << 3
+    CCR_DIV_0_TRP      = 1 << 4
+    CCR_STKALIGN       = 1 << 9
+)
+
+/* System Handler Control and State Register enable bits
+ * ARMv7-M ARM B3.2.13 */
+const (
+    SHCSR_MEMFAULTENA = 1 << 16
+    SHCSR_BUSFAULTENA = 1 << 17
+    SHCSR_USGFAULTENA = 1 << 18
+)
+
+/* Configurable Fault Status Register bits, other than the UsageFault
+ * Status Register in the top halfword
+ * ARMv7-M ARM B3.2.15 */
+const (
+    CFSR_IBUSERR   = 1 << 8
+    CFSR_PRECISERR = 1 << 9
+    CFSR_BFARVALID = 1 << 15
+)
+
+/* HardFault Status Register bits
+ * ARMv7-M ARM B3.2.16 */
+const (
+    HFSR_VECTTBL = 1 << 1
+    HFSR_FORCED  = 1 << 30
+)
+
+/* The System Control Block registers that are implemented.  Everything
+ * else in the SCS reads as zero and ignores writes. */
+type SystemControl struct {
+    Vtor  uint32
+    Ccr   uint32
+    Shcsr uint32
+    Cfsr  uint32
+    Hfsr  uint32
+    Bfar  uint32
+}
+
+func (scs *SystemControl) Reset() {
+    *scs = SystemControl{Vtor: VECTOR_TABLE, Ccr: CCR_STKALIGN}
+}
+
+func (scs *SystemControl) read(offset uint32) uint32 {
+    switch offset {
+    case SCS_CPUID:
+        return CPUID
+    case SCS_VTOR:
+        return scs.Vtor
+    case SCS_CCR:
+        return scs.Ccr
+    case SCS_SHCSR:
+        return scs.Shcsr
+    case SCS_CFSR:
+        return scs.Cfsr
+    case SCS_HFSR:
+        return scs.Hfsr
+    case SCS_BFAR:
+        return scs.Bfar
+    }
+
+    return 0
+}
+
+/* Write the bytes of the register at offset selected by lanes */
+func (scs *SystemControl) write(offset uint32, value uint32, lanes uint32) {
+    merge := func(reg uint32, writable uint32) uint32 {
+        return (reg &^ (lanes & writable)) | (value & lanes & writable)
+    }
+
+    switch offset {
+    case SCS_VTOR:
+        scs.Vtor = merge(scs.Vtor, 0xffffff80)
+    case SCS_CCR:
+        scs.Ccr = merge(scs.Ccr, CCR_NONBASETHRDENA|CCR_UNALIGN_TRP|CCR_DIV_0_TRP|CCR_STKALIGN)
+    case SCS_SHCSR:
+        scs.Shcsr = merge(scs.Shcsr, SHCSR_MEMFAULTENA|SHCSR_BUSFAULTENA|SHCSR_USGFAULTENA)
+    case SCS_CFSR:
+        /* Write one to clear */
+        scs.Cfsr &^= value & lanes
+    case SCS_HFSR:
+        scs.Hfsr &^= value & lanes
+    case SCS_BFAR:
+        scs.Bfar = merge(scs.Bfar, 0xffffffff)
+    }
+}
+
+func (scs *SystemControl) check(addr uint32, size uint32, write bool) error {
+    if uint64(addr)+uint64(size) > SCS_SIZE || addr%size != 0 {
+        return BusError{Addr: SCS_BASE + addr, Size: size, Write: write}
+    }
+    return nil
+}
+
+func (scs *SystemControl) Read8(addr uint32) (uint8, error) {
+    if err := scs.check(addr, 1, false); err != nil {
+        return 0, err
+    }
+    return uint8(scs.read(addr&^0x3) >> (8 * (addr & 0x3))), nil
+}
+
+func (scs *SystemControl) Read16(addr uint32) (uint16, error) {
+    if err := scs.check(addr, 2, false); err != nil {
+        return 0, err
+    }
+    return uint16(scs.read(addr&^0x3) >> (8 * (addr & 0x3))), nil
+}
+
+func (scs *SystemControl) Read32(addr uint32) (uint32, error) {
+    if err := scs.check(addr, 4, false); err != nil {
+        return 0, err
+    }
+    return scs.read(addr), nil
+}
+
+func (scs *SystemControl) Write8(addr uint32, value uint8) error {
+    if err := scs.check(addr, 1, true); err != nil {
+        return err
+    }
+    shift := 8 * (addr & 0x3)
+    scs.write(addr&^0x3, uint32(value)<<shift, 0xff<<shift)
+    return nil
+}
+
+func (scs *SystemControl) Write16(addr uint32, value uint16) error {
+    if err := scs.check(addr, 2, true); err != nil {
+        return err
+    }
+    shift := 8 * (addr & 0x3)
+    scs.write(addr&^0x3, uint32(value)<<shift, 0xffff<<shift)
+    return nil
+}
+
+func (scs *SystemControl) Write32(addr uint32, value uint32) error {
+    if err := scs.check(addr, 4, true); err != nil {
+        return err
+    }
+    scs.write(addr, value, 0xffffffff)
+    return nil
+}
+
+/* Memory as seen by the processor, with the SCS in front of the system bus */
+type processor_bus struct {
+    cpu *CPU
+}
+
+func (bus processor_bus) route(addr uint32) (Memory, uint32) {
+    if addr >= SCS_BASE && addr-SCS_BASE < SCS_SIZE {
+        return &bus.cpu.Scs, addr - SCS_BASE
+    }
+    return bus.cpu.Mem, addr
+}
+
+func (bus processor_bus) Read8(addr uint32) (uint8, error) {
+    mem, addr := bus.route(addr)
+    return mem.Read8(addr)
+}
+
+func (bus processor_bus) Read16(addr uint32) (uint16, error) {
+    mem, addr := bus.route(addr)
+    return mem.Read16(addr)
+}
+
+func (bus processor_bus) Read32(addr uint32) (uint32, error) {
+    mem, addr := bus.route(addr)
+    return mem.Read32(addr)
+}
+
+func (bus processor_bus) Write8(addr uint32, value uint8) error {
+    mem, addr := bus.route(addr)
+    return mem.Write8(addr, value)
+}
+
+func (bus processor_bus) Write16(addr uint32, value uint16) error {
+    mem, addr := bus.route(addr)
+    return mem.Write16(addr, value)
+}
+
+func (bus processor_bus) Write32(addr uint32, value uint32) error {
+    mem, addr := bus.route(addr)
+    return mem.Write32(addr, value)
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+    "testing"
+)
+
+func TestSystemControlRegisters(t *testing.T) {
+    var scs SystemControl
+    scs.Reset()
+
+    if value, _ := scs.Read32(SCS_CPUID); value != CPUID {
+        t.Errorf("CPUID = %#x, expected %#x", value, CPUID)
+    }
+
+    if value, _ := scs.Read32(SCS_CCR); value != CCR_STKALIGN {
+        t.Errorf("CCR = %#x, expected %#x", value, CCR_STKALIGN)
+    }
+
+    /* Reserved bits ignore writes */
+    scs.Write32(SCS_VTOR, 0x12345678)
+    if scs.Vtor != 0x12345600 {
+        t.Errorf("VTOR = %#x, expected 0x12345600", scs.Vtor)
+    }
+
+    /* Byte writes only change their own lane */
+    scs.Write8(SCS_SHCSR+2, 0x04)
+    if scs.Shcsr != SHCSR_USGFAULTENA {
+        t.Errorf("SHCSR = %#x, expected %#x", scs.Shcsr, SHCSR_USGFAULTENA)
+    }
+
+    if value, _ := scs.Read16(SCS_SHCSR + 2); value != 0x4 {
+        t.Errorf("SHCSR[31:16] = %#x, expected 0x4", value)
+    }
+}
+
+func TestSystemControlWriteOneToClear(t *testing.T) {
+    var scs SystemControl
+    scs.Reset()
+
+    scs.Cfsr = uint32(UFSR_INVSTATE|UFSR_UNDEFINSTR)<<16 | CFSR_PRECISERR
+    scs.Hfsr = HFSR_FORCED
+
+    scs.Write16(SCS_CFSR+2, uint16(UFSR_INVSTATE))
+    if scs.Cfsr != uint32(UFSR_UNDEFINSTR)<<16|CFSR_PRECISERR {
+        t.Errorf("CFSR = %#x after clearing INVSTATE", scs.Cfsr)
+    }
+
+    scs.Write32(SCS_CFSR, 0xffffffff)
+    scs.Write32(SCS_HFSR, 0)
+    if scs.Cfsr != 0 || scs.Hfsr != HFSR_FORCED {
+        t.Errorf("CFSR = %#x, HFSR = %#x", scs.Cfsr, scs.Hfsr)
+    }
+}
+
+func TestSystemControlBusErrors(t *testing.T) {
+    var scs SystemControl
+
+    if _, err := scs.Read32(SCS_CCR + 2); err != (BusError{Addr: SCS_BASE + SCS_CCR + 2, Size: 4}) {
+        t.Errorf("Unaligned read: %v", err)
+    }
+
+    if err := scs.Write8(SCS_SIZE, 0); err != (BusError{Addr: SCS_BASE + SCS_SIZE, Size: 1, Write: true}) {
+        t.Errorf("Write outside of the SCS: %v", err)
+    }
+}
+
+func TestProcessorBus(t *testing.T) {
+    cpu := NewCPU(make(Block, 16))
+    bus := processor_bus{cpu: cpu}
+
+    bus.Write32(SCS_BASE+SCS_CCR, CCR_DIV_0_TRP)
+    bus.Write32(4, 0xcafe)
+
+    if cpu.Scs.Ccr != CCR_DIV_0_TRP {
+        t.Errorf("CCR = %#x, expected %#x", cpu.Scs.Ccr, CCR_DIV_0_TRP)
+    }
+
+    if value, _ := cpu.Mem.Read32(4); value != 0xcafe {
+        t.Errorf("Memory at 4 = %#x, expected 0xcafe", value)
+    }
+}
@@ -21,12 +21,14 @@ func LslImm16(instr FetchedInstr) DecodedInstr {
     return LslImm{Rd: Rd, Rm: Rm, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr LslImm) Execute(regs *Registers) {
+func (instr LslImm) Execute(regs *Registers, mem Memory) error {
     value := regs.R(instr.Rm)
     shift_n := uint8(instr.Imm)
 
     result := LSL(regs, value, shift_n, instr.setflags)
     regs.SetR(instr.Rd, result)
+
+    return nil
 }
 
 func (instr LslImm) String() string {
@@ -46,12 +48,14 @@ func LslReg16(instr FetchedInstr) DecodedInstr {
     return LslReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr LslReg) Execute(regs *Registers) {
+func (instr LslReg) Execute(regs *Registers, mem Memory) error {
     value := regs.R(instr.Rn)
     shift_n := uint8(regs.R(instr.Rm))
 
     result := LSL(regs, value, shift_n, instr.setflags)
     regs.SetR(instr.Rd, result)
+
+    return nil
 }
 
 func (instr LslReg) String() string {
@@ -67,17 +71,19 @@ func LsrImm16(instr FetchedInstr) DecodedInstr {
 
     Rd := RegIndex(raw_instr & 0x7)
     Rm := RegIndex((raw_instr >> 3) & 0x7)
-    Imm := (raw_instr >> 6) & 0x1f
+    Imm := uint32(DecodeImmShift(0x1, (raw_instr>>6)&0x1f).amount)
 
     return LsrImm{Rd: Rd, Rm: Rm, Rn: 0, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr LsrImm) Execute(regs *Registers) {
+func (instr LsrImm) Execute(regs *Registers, mem Memory) error {
     value := regs.R(instr.Rm)
     shift_n := uint8(instr.Imm)
 
     result := LSR(regs, value, shift_n, instr.setflags)
     regs.SetR(instr.Rd, result)
+
+    return nil
 }
 
 func (instr LsrImm) String() string {
@@ -97,12 +103,14 @@ func LsrReg16(instr FetchedInstr) DecodedInstr {
     return LsrReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
 }
 
-func (instr LsrReg) Execute(regs *Registers) {
+func (instr LsrReg) Execute(regs *Registers, mem Memory) error {
     value := regs.R(instr.Rn)
     shift_n := uint8(regs.R(instr.Rm))
 
     result := LSR(regs, value, shift_n, instr.setflags)
     regs.SetR(instr.Rd, result)
+
+    return nil
 }
 
 func (instr LsrReg) String() string {
@@ -118,23 +126,210 @@ func AsrImm16(instr FetchedInstr) DecodedInstr {
 
     Rd := RegIndex(raw_instr & 0x7)
     Rm := RegIndex((raw_instr >> 3) & 0x7)
-    Imm := (raw_instr >> 6) & 0x1f
-
-    if Imm == 0 {
-        Imm = 32
-    }
+    Imm := uint32(DecodeImmShift(0x2, (raw_instr>>6)&0x1f).amount)
 
     return AsrImm{Rd: Rd, Rn: 0, Rm: Rm, Imm: Imm, setflags: NOT_IT}
 }
 
-func (instr AsrImm) Execute(regs *Registers) {
+func (instr AsrImm) Execute(regs *Registers, mem Memory) error {
     value := regs.R(instr.Rm)
     shift_n := uint8(instr.Imm)
 
     result := ASR(regs, value, shift_n, instr.setflags)
     regs.SetR(instr.Rd, result)
+
+    return nil
 }
 
 func (instr AsrImm) String() string {
     return fmt.Sprintf("asr%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
 }
+
+/* ASR - Arithmetic Shift Right (register)
+ * ARM ARM A7.7.11 */
+type AsrReg InstrFields
+
+func AsrReg16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return AsrReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr AsrReg) Execute(regs *Registers, mem Memory) error {
+    value := regs.R(instr.Rn)
+    shift_n := uint8(regs.R(instr.Rm))
+
+    result := ASR(regs, value, shift_n, instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr AsrReg) String() string {
+    return fmt.Sprintf("asr%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* ROR - Rotate Right (register)
+ * ARM ARM A7.7.115 */
+type RorReg InstrFields
+
+func RorReg16(instr FetchedInstr) DecodedInstr {
+    raw_instr := instr.Uint32()
+
+    Rdn := RegIndex(raw_instr & 0x7)
+    Rm := RegIndex((raw_instr >> 3) & 0x7)
+
+    return RorReg{Rd: Rdn, Rn: Rdn, Rm: Rm, Imm: 0, setflags: NOT_IT}
+}
+
+func (instr RorReg) Execute(regs *Registers, mem Memory) error {
+    value := regs.R(instr.Rn)
+    shift_n := uint8(regs.R(instr.Rm))
+
+    result := ROR(regs, value, shift_n, instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr RorReg) String() string {
+    return fmt.Sprintf("ror%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
+
+/* LSL - Logical Shift Left (immediate)
+ * ARM ARM A7.7.67
+ * Encoding T2 */
+type LslImmT2 InstrFields
+
+func LslImm32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    if shift.amount == 0 {
+        /* Equivalent to MOV (reg) T3 encoding */
+        return MovReg32T3(instr)
+    }
+
+    return LslImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: uint32(shift.amount), setflags: setflags}
+}
+
+func (instr LslImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    result := LSL(regs, regs.R(instr.Rm), uint8(instr.Imm), instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr LslImmT2) String() string {
+    return fmt.Sprintf("lsl%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
+}
+
+/* LSR - Logical Shift Right (immediate)
+ * ARM ARM A7.7.69
+ * Encoding T2 */
+type LsrImmT2 InstrFields
+
+func LsrImm32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return LsrImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: uint32(shift.amount), setflags: setflags}
+}
+
+func (instr LsrImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    result := LSR(regs, regs.R(instr.Rm), uint8(instr.Imm), instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr LsrImmT2) String() string {
+    return fmt.Sprintf("lsr%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
+}
+
+/* ASR - Arithmetic Shift Right (immediate)
+ * ARM ARM A7.7.10
+ * Encoding T2 */
+type AsrImmT2 InstrFields
+
+func AsrImm32T2(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return AsrImmT2{Rd: Rd, Rm: Rm, Rn: 0, Imm: uint32(shift.amount), setflags: setflags}
+}
+
+func (instr AsrImmT2) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    result := ASR(regs, regs.R(instr.Rm), uint8(instr.Imm), instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr AsrImmT2) String() string {
+    return fmt.Sprintf("asr%s.w %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
+}
+
+/* ROR - Rotate Right (immediate)
+ * ARM ARM A7.7.114
+ * Encoding T1 */
+type RorImmT1 InstrFields
+
+func RorImm32T1(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, shift, setflags := shifted_reg_fields(instr.Uint32())
+
+    return RorImmT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: uint32(shift.amount), setflags: setflags}
+}
+
+func (instr RorImmT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    result := ROR(regs, regs.R(instr.Rm), uint8(instr.Imm), instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr RorImmT1) String() string {
+    return fmt.Sprintf("ror%s %s, %s, #%d", instr.setflags, instr.Rd, instr.Rm, instr.Imm)
+}
+
+/* RRX - Rotate Right with Extend
+ * ARM ARM A7.7.116
+ * Encoding T1 */
+type RrxT1 InstrFields
+
+func Rrx32T1(instr FetchedInstr) DecodedInstr {
+    Rd, _, Rm, _, setflags := shifted_reg_fields(instr.Uint32())
+
+    return RrxT1{Rd: Rd, Rm: Rm, Rn: 0, Imm: 0, setflags: setflags}
+}
+
+func (instr RrxT1) Execute(regs *Registers, mem Memory) error {
+    if bad_reg(instr.Rd) || bad_reg(instr.Rm) {
+        return UnpredictableInstr(instr).Execute(regs, mem)
+    }
+
+    result := ShiftOp(regs, regs.R(instr.Rm), Shift{srtype: SRTYPE_RRX, amount: 1}, instr.setflags)
+    regs.SetR(instr.Rd, result)
+
+    return nil
+}
+
+func (instr RrxT1) String() string {
+    return fmt.Sprintf("rrx%s %s, %s", instr.setflags, instr.Rd, instr.Rm)
+}
@@ -116,7 +116,7 @@ func TestExecuteLslReg(t *testing.T) {
 
 func TestIdentifyLsrImm(t *testing.T) {
     cases := []IdentifyCase{
-        {instr: FetchedInstr16(0x0800), instr_valid: true},  // lsr r0, r0, #0
+        {instr: FetchedInstr16(0x0800), instr_valid: true},  // lsr r0, r0, #32 (imm of 0 becomes imm of 32)
         {instr: FetchedInstr16(0x09e7), instr_valid: true},  // lsr r7, r4, #7
         {instr: FetchedInstr16(0x40c0), instr_valid: false}, // lsr r0, r0, r0
         {instr: FetchedInstr16(0x40d3), instr_valid: false}, // lsr r3, r3, r1
@@ -130,8 +130,8 @@ func TestIdentifyLsrImm(t *testing.T) {
 
 func TestDecodeLsrImm16(t *testing.T) {
     cases := []DecodeCase{
-        // lsr r0, r0, #0
-        {instr: FetchedInstr16(0x0800), decoded: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 0, setflags: NOT_IT}},
+        // lsr r0, r0, #32
+        {instr: FetchedInstr16(0x0800), decoded: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 32, setflags: NOT_IT}},
         // lsr r0, r0, #1
         {instr: FetchedInstr16(0x0840), decoded: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 1, setflags: NOT_IT}},
         // lsr r7, r4, #7
@@ -143,10 +143,10 @@ func TestDecodeLsrImm16(t *testing.T) {
 
 func TestExecuteLsrImm(t *testing.T) {
     cases := []ExecuteCase{
-        // lsr r0, r0, #0
-        {instr: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 0, setflags: NOT_IT},
-            regs:     Registers{r: GeneralRegs{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
-            expected: Registers{r: GeneralRegs{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}},
+        // lsr r0, r0, #32
+        {instr: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 32, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x80000001, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true, Z: true}}},
         // lsr r0, r0, #1
         {instr: LsrImm{Rd: 0, Rm: 0, Rn: 0, Imm: 1, setflags: NOT_IT},
             regs:     Registers{r: GeneralRegs{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
@@ -262,3 +262,246 @@ func TestExecuteAsrImm(t *testing.T) {
 
     test_execute(t, cases)
 }
+
+func TestIdentifyAsrReg(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x413e), instr_valid: true},  // asrs r6, r7
+        {instr: FetchedInstr16(0x41ec), instr_valid: false}, // rors r4, r5
+        {instr: FetchedInstr16(0x1000), instr_valid: false}, // asrs r0, r0, #32
+    }
+
+    test_identify(t, cases, reflect.TypeOf(AsrReg{}))
+}
+
+func TestDecodeAsrReg16(t *testing.T) {
+    cases := []DecodeCase{
+        // asrs r6, r7
+        {instr: FetchedInstr16(0x413e), decoded: AsrReg{Rd: 6, Rn: 6, Rm: 7, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, AsrReg16)
+}
+
+func TestExecuteAsrReg(t *testing.T) {
+    cases := []ExecuteCase{
+        // asrs r6, r7
+        {instr: AsrReg{Rd: 6, Rn: 6, Rm: 7, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0x80000010, 4, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0xf8000001, 4, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true}}},
+        // asrs r6, r7
+        {instr: AsrReg{Rd: 6, Rn: 6, Rm: 7, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0x18, 4, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0, 0, 0x1, 4, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestIdentifyRorReg(t *testing.T) {
+    cases := []IdentifyCase{
+        {instr: FetchedInstr16(0x41ec), instr_valid: true},  // rors r4, r5
+        {instr: FetchedInstr16(0x413e), instr_valid: false}, // asrs r6, r7
+    }
+
+    test_identify(t, cases, reflect.TypeOf(RorReg{}))
+}
+
+func TestDecodeRorReg16(t *testing.T) {
+    cases := []DecodeCase{
+        // rors r4, r5
+        {instr: FetchedInstr16(0x41ec), decoded: RorReg{Rd: 4, Rn: 4, Rm: 5, Imm: 0, setflags: NOT_IT}},
+    }
+
+    test_decode(t, cases, RorReg16)
+}
+
+func TestExecuteRorReg(t *testing.T) {
+    cases := []ExecuteCase{
+        // rors r4, r5
+        {instr: RorReg{Rd: 4, Rn: 4, Rm: 5, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0x81, 4, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0x10000008, 4, 0, 0, 0, 0, 0, 0, 0}}},
+        // rors r4, r5, the carry is the top bit of the result
+        {instr: RorReg{Rd: 4, Rn: 4, Rm: 5, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0x1, 1, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0x80000000, 1, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+        // rors r4, r5, a multiple of 32 leaves the value but updates carry
+        {instr: RorReg{Rd: 4, Rn: 4, Rm: 5, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0x80000000, 32, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0x80000000, 32, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+        // rors r4, r5, zero leaves carry alone
+        {instr: RorReg{Rd: 4, Rn: 4, Rm: 5, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0, 0, 0, 0, 0x1, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 0, 0, 0, 0x1, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestExecuteShiftRegLarge(t *testing.T) {
+    cases := []ExecuteCase{
+        // lsl r0, r0, r1, by 32 carries out bit 0
+        {instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x00000001, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true, Z: true}}},
+        // lsl r0, r0, r1, by more than 32 carries out 0
+        {instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 33, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 33, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{Z: true}}},
+        // lsl r0, r0, r1, by 255
+        {instr: LslReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 0x1ff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 0x1ff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{Z: true}}},
+        // lsr r0, r0, r1, by 32 carries out bit 31
+        {instr: LsrReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x80000000, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true, Z: true}}},
+        // lsr r0, r0, r1, by more than 32 carries out 0
+        {instr: LsrReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0xffffffff, 40, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 40, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{Z: true}}},
+        // asr r0, r0, r1, by more than 32 fills with the sign bit
+        {instr: AsrReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x80000000, 33, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0xffffffff, 33, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+        // asr r0, r0, r1, by 200
+        {instr: AsrReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x7fffffff, 200, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{0, 200, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{Z: true}}},
+        // ror r0, r0, r1, by 36 rotates by 4
+        {instr: RorReg{Rd: 0, Rn: 0, Rm: 1, Imm: 0, setflags: NOT_IT},
+            regs:     Registers{r: GeneralRegs{0x12345678, 36, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+            expected: Registers{r: GeneralRegs{0x81234567, 36, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Apsr: Apsr{N: true, C: true}}},
+    }
+
+    test_execute(t, cases)
+}
+
+func TestDecodeImmShift(t *testing.T) {
+    cases := []struct {
+        srtype   uint32
+        imm5     uint32
+        shift    Shift
+        value    uint32
+        carry_in bool
+        result   uint32
+        carry    bool
+    }{
+        // lsl #0 leaves the carry alone
+        {srtype: 0x0, imm5: 0, shift: Shift{srtype: SRTYPE_LSL, amount: 0}, value: 0x80000001, carry_in: true, result: 0x80000001, carry: true},
+        {srtype: 0x0, imm5: 4, shift: Shift{srtype: SRTYPE_LSL, amount: 4}, value: 0x18000001, carry_in: false, result: 0x80000010, carry: true},
+        // lsr #32
+        {srtype: 0x1, imm5: 0, shift: Shift{srtype: SRTYPE_LSR, amount: 32}, value: 0x80000000, carry_in: false, result: 0, carry: true},
+        {srtype: 0x1, imm5: 1, shift: Shift{srtype: SRTYPE_LSR, amount: 1}, value: 0x80000001, carry_in: false, result: 0x40000000, carry: true},
+        // asr #32
+        {srtype: 0x2, imm5: 0, shift: Shift{srtype: SRTYPE_ASR, amount: 32}, value: 0x80000000, carry_in: false, result: 0xffffffff, carry: true},
+        {srtype: 0x2, imm5: 0, shift: Shift{srtype: SRTYPE_ASR, amount: 32}, value: 0x7fffffff, carry_in: true, result: 0, carry: false},
+        {srtype: 0x2, imm5: 4, shift: Shift{srtype: SRTYPE_ASR, amount: 4}, value: 0x80000008, carry_in: false, result: 0xf8000000, carry: true},
+        {srtype: 0x3, imm5: 8, shift: Shift{srtype: SRTYPE_ROR, amount: 8}, value: 0x12345678, carry_in: false, result: 0x78123456, carry: false},
+        // rrx
+        {srtype: 0x3, imm5: 0, shift: Shift{srtype: SRTYPE_RRX, amount: 1}, value: 0x00000003, carry_in: true, result: 0x80000001, carry: true},
+        {srtype: 0x3, imm5: 0, shift: Shift{srtype: SRTYPE_RRX, amount: 1}, value: 0x80000002, carry_in: false, result: 0x40000001, carry: false},
+    }
+
+    for _, test := range cases {
+        shift := DecodeImmShift(test.srtype, test.imm5)
+        if shift != test.shift {
+            t.Errorf("type %d, imm5 %d: %#v, expected %#v", test.srtype, test.imm5, shift, test.shift)
+        }
+
+        result, carry := shift.EvaluateC(test.value, test.carry_in)
+
+        if result != test.result || carry != test.carry {
+            t.Errorf("type %d, imm5 %d, %#x: %#x, %v, expected %#x, %v",
+                test.srtype, test.imm5, test.value, result, carry, test.result, test.carry)
+        }
+    }
+}
+
+func TestThumbExpandImm(t *testing.T) {
+    cases := []struct {
+        imm12    uint32
+        carry_in bool
+        result   uint32
+        carry    bool
+    }{
+        {imm12: 0x0ab, carry_in: true, result: 0x000000ab, carry: true},
+        {imm12: 0x1ab, carry_in: false, result: 0x00ab00ab, carry: false},
+        {imm12: 0x2ab, carry_in: true, result: 0xab00ab00, carry: true},
+        {imm12: 0x3ab, carry_in: false, result: 0xabababab, carry: false},
+        // '1':imm12<6:0> rotated right by imm12<11:7>
+        {imm12: 0x400, carry_in: false, result: 0x80000000, carry: true},
+        {imm12: 0x47f, carry_in: false, result: 0xff000000, carry: true},
+        {imm12: 0xf80, carry_in: true, result: 0x00000100, carry: false},
+        {imm12: 0xfff, carry_in: true, result: 0x000001fe, carry: false},
+    }
+
+    for _, test := range cases {
+        result, carry := ThumbExpandImm_C(test.imm12, test.carry_in)
+
+        if result != test.result || carry != test.carry {
+            t.Errorf("%#x: %#x, %v, expected %#x, %v", test.imm12, result, carry, test.result, test.carry)
+        }
+    }
+}
+
+func TestThumbExpandImmCarry(t *testing.T) {
+    cases := []struct {
+        imm12  uint32
+        result uint32
+        carry  ImmCarry
+    }{
+        {imm12: 0x3ab, result: 0xabababab, carry: CARRY_IN},
+        {imm12: 0x400, result: 0x80000000, carry: CARRY_SET},
+        {imm12: 0xf80, result: 0x00000100, carry: CARRY_CLEAR},
+    }
+
+    for _, test := range cases {
+        result, carry := ThumbExpandImmCarry(test.imm12)
+        if result != test.result || carry != test.carry {
+            t.Errorf("%#x: %#x, %v, expected %#x, %v", test.imm12, result, carry, test.result, test.carry)
+        }
+
+        for _, carry_in := range []bool{false, true} {
+            _, expected := ThumbExpandImm_C(test.imm12, carry_in)
+            if carry.Evaluate(carry_in) != expected {
+                t.Errorf("%#x: carry out with carry in %v, expected %v", test.imm12, carry_in, expected)
+            }
+        }
+    }
+}
+
+func TestExecuteShiftImmT2(t *testing.T) {
+    cases := []ExecuteCase{
+        // lsl.w r4, r5, #7
+        {instr: LslImmT2{Rd: 4, Rm: 5, Rn: 0, Imm: 7, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 3, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 0x180, 3, 6, 7, 8, 9, 10, 11, 12}}},
+        // lsrs.w r6, r7, #32
+        {instr: LsrImmT2{Rd: 6, Rm: 7, Rn: 0, Imm: 32, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 0x80000000, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 0, 0x80000000, 8, 9, 10, 11, 12}, Apsr: Apsr{Z: true, C: true}}},
+        // asr.w r8, r9, #1
+        {instr: AsrImmT2{Rd: 8, Rm: 9, Rn: 0, Imm: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 0x80000000, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 0xc0000000, 0x80000000, 10, 11, 12}}},
+        // ror r10, r11, #12
+        {instr: RorImmT1{Rd: 10, Rm: 11, Rn: 0, Imm: 12, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 0x123, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0x12300000, 0x123, 12}}},
+        // rrxs r12, r0
+        {instr: RrxT1{Rd: 12, Rm: 0, Rn: 0, Imm: 0, setflags: ALWAYS},
+            regs:     Registers{r: GeneralRegs{1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0}, Apsr: Apsr{Z: true, C: true}}},
+        // rrx r12, r0, with carry in
+        {instr: RrxT1{Rd: 12, Rm: 0, Rn: 0, Imm: 0, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Apsr: Apsr{C: true}},
+            expected: Registers{r: GeneralRegs{2, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 0x80000001}, Apsr: Apsr{C: true}}},
+        // lsl.w sp, r5, #1 (UNPREDICTABLE)
+        {instr: LslImmT2{Rd: SP, Rm: 5, Rn: 0, Imm: 1, setflags: NEVER},
+            regs:     Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+            expected: Registers{r: GeneralRegs{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}}},
+    }
+
+    test_execute(t, cases)
+}
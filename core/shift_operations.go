@@ -1,34 +1,98 @@
 package core
 
+import "fmt"
+
 /* Generic shifting function
  *
  * @param value uint32      Value to shift
  * @param amount uint8      Amount to shift by
+ * @param carry_in bool     Carry flag, only shifted in by RRX
  *
  * @return result uint32    Result of shift
  * @return carry bool       Carry out of shift
  */
-type ShiftFunc func(uint32, uint8) (uint32, bool)
+type ShiftFunc func(uint32, uint8, bool) (uint32, bool)
+
+/* Shift types
+ * ARM ARM A7.4.2 */
+type SRType uint8
+
+const (
+    SRTYPE_LSL SRType = iota
+    SRTYPE_LSR
+    SRTYPE_ASR
+    SRTYPE_ROR
+    SRTYPE_RRX
+)
+
+var shift_functions = [...]ShiftFunc{
+    SRTYPE_LSL: LSL_C,
+    SRTYPE_LSR: LSR_C,
+    SRTYPE_ASR: ASR_C,
+    SRTYPE_ROR: ROR_C,
+    SRTYPE_RRX: RRX_C,
+}
 
+/* Shift applied to an operand.  It holds the shift type rather than its
+ * ShiftFunc, so that decoded instructions can be compared. */
 type Shift struct {
-    function ShiftFunc
-    amount   uint8
+    srtype SRType
+    amount uint8
 }
 
-func (shift Shift) Evaluate(input uint32) (uint32, bool) {
-    return shift.function(input, shift.amount)
+var shift_names = [...]string{
+    SRTYPE_LSL: "lsl",
+    SRTYPE_LSR: "lsr",
+    SRTYPE_ASR: "asr",
+    SRTYPE_ROR: "ror",
+    SRTYPE_RRX: "rrx",
 }
 
-/* Perform shift operation, updating condition codes */
-func ShiftOp(regs *Registers, value uint32, shift_n uint8, setflags SetFlags, do_shift ShiftFunc) uint32 {
-    var result uint32
-    var carry_out bool
-
-    if shift_n == 0 {
-        result, carry_out = value, regs.Apsr.C
-    } else {
-        result, carry_out = do_shift(value, shift_n)
+/* Shift suffix of an operand, empty when the operand is not shifted */
+func (shift Shift) String() string {
+    if shift.srtype == SRTYPE_RRX {
+        return ", rrx"
+    } else if shift.amount == 0 {
+        return ""
     }
+    return fmt.Sprintf(", %s #%d", shift_names[shift.srtype], shift.amount)
+}
+
+/* Shift_C() from the ARM ARM, where a shift by 0 carries out carry_in */
+func (shift Shift) EvaluateC(input uint32, carry_in bool) (uint32, bool) {
+    if shift.amount == 0 {
+        return input, carry_in
+    }
+    return shift_functions[shift.srtype](input, shift.amount, carry_in)
+}
+
+/* Decode the type and amount of a shift from an immediate encoding
+ * ARM ARM A7.4.2 */
+func DecodeImmShift(srtype uint32, imm5 uint32) Shift {
+    switch srtype & 0x3 {
+    case 0x0:
+        return Shift{srtype: SRTYPE_LSL, amount: uint8(imm5)}
+    case 0x1:
+        if imm5 == 0 {
+            return Shift{srtype: SRTYPE_LSR, amount: 32}
+        }
+        return Shift{srtype: SRTYPE_LSR, amount: uint8(imm5)}
+    case 0x2:
+        if imm5 == 0 {
+            return Shift{srtype: SRTYPE_ASR, amount: 32}
+        }
+        return Shift{srtype: SRTYPE_ASR, amount: uint8(imm5)}
+    default:
+        if imm5 == 0 {
+            return Shift{srtype: SRTYPE_RRX, amount: 1}
+        }
+        return Shift{srtype: SRTYPE_ROR, amount: uint8(imm5)}
+    }
+}
+
+/* Perform shift operation, updating condition codes */
+func ShiftOp(regs *Registers, value uint32, shift Shift, setflags SetFlags) uint32 {
+    result, carry_out := shift.EvaluateC(value, regs.Apsr.C)
 
     if setflags.ShouldSetFlags(*regs) {
         regs.Apsr.N = (result & 0x80000000) != 0
@@ -41,11 +105,11 @@ func ShiftOp(regs *Registers, value uint32, shift_n uint8, setflags SetFlags, do
 
 /* Perform LSL instruction, updating condition codes */
 func LSL(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-    return ShiftOp(regs, value, shift_n, setflags, LSL_C)
+    return ShiftOp(regs, value, Shift{srtype: SRTYPE_LSL, amount: shift_n}, setflags)
 }
 
 /* Left shift value by a positive amount */
-func LSL_C(value uint32, amount uint8) (uint32, bool) {
+func LSL_C(value uint32, amount uint8, carry_in bool) (uint32, bool) {
     extended := uint64(value)
 
     extended = extended << amount
@@ -58,32 +122,122 @@ func LSL_C(value uint32, amount uint8) (uint32, bool) {
 
 /* Perform LSR instruction, updating condition codes */
 func LSR(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-    return ShiftOp(regs, value, shift_n, setflags, LSR_C)
+    return ShiftOp(regs, value, Shift{srtype: SRTYPE_LSR, amount: shift_n}, setflags)
 }
 
-/* Right shift value by a positive amount */
-func LSR_C(value uint32, amount uint8) (uint32, bool) {
-    /* The last bit to be carried out determines the carry */
-    carry_out := (value & (1 << (amount - 1))) != 0
+/* Right shift value by a positive amount, which may be 32 or more */
+func LSR_C(value uint32, amount uint8, carry_in bool) (uint32, bool) {
+    /* Keep the last bit to be carried out below the result */
+    extended := (uint64(value) << 1) >> amount
 
-    result := value >> amount
+    result := uint32(extended >> 1)
+    carry_out := (extended & 0x1) != 0
 
     return result, carry_out
 }
 
 /* Perform ASR instruction, updating condition codes */
 func ASR(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
-    return ShiftOp(regs, value, shift_n, setflags, ASR_C)
+    return ShiftOp(regs, value, Shift{srtype: SRTYPE_ASR, amount: shift_n}, setflags)
+}
+
+/* Right shift value by a positive amount, copying the leftmost bit.  Amounts
+ * of 32 or more fill both the result and the carry with the leftmost bit. */
+func ASR_C(value uint32, amount uint8, carry_in bool) (uint32, bool) {
+    /* Keep the last bit to be carried out below the result */
+    extended := (int64(int32(value)) << 1) >> amount
+
+    result := uint32(extended >> 1)
+    carry_out := (extended & 0x1) != 0
+
+    return result, carry_out
+}
+
+/* Perform ROR instruction, updating condition codes */
+func ROR(regs *Registers, value uint32, shift_n uint8, setflags SetFlags) uint32 {
+    return ShiftOp(regs, value, Shift{srtype: SRTYPE_ROR, amount: shift_n}, setflags)
 }
 
-/* Right shift value by a positive amount, copying the leftmost bit */
-func ASR_C(value uint32, amount uint8) (uint32, bool) {
-    /* The last bit to be carried out determines the carry */
-    carry_out := (value & (1 << (amount - 1))) != 0
+/* Rotate value right by a positive amount */
+func ROR_C(value uint32, amount uint8, carry_in bool) (uint32, bool) {
+    m := amount % 32
 
-    extended := int32(value)
+    result := (value >> m) | (value << (32 - m))
+    carry_out := (result & 0x80000000) != 0
 
-    result := extended >> amount
+    return result, carry_out
+}
 
-    return uint32(result), carry_out
+/* Rotate value right by one bit, shifting carry_in into the leftmost bit.
+ * The amount is always 1. */
+func RRX_C(value uint32, amount uint8, carry_in bool) (uint32, bool) {
+    result := (uint32(booltou(carry_in)) << 31) | (value >> 1)
+    carry_out := (value & 0x1) != 0
+
+    return result, carry_out
+}
+
+/* Expand the modified immediate constant of a 32-bit instruction
+ * ARM ARM A5.3.2 */
+func ThumbExpandImm(imm12 uint32) uint32 {
+    imm32, _ := ThumbExpandImm_C(imm12, false)
+    return imm32
+}
+
+/* Expand the modified immediate constant of a 32-bit instruction, carrying
+ * out the top bit of a rotated constant or else carry_in
+ *
+ * The byte patterns with an imm8 of 0 are UNPREDICTABLE, and expand to 0. */
+func ThumbExpandImm_C(imm12 uint32, carry_in bool) (uint32, bool) {
+    imm8 := imm12 & 0xff
+
+    if (imm12 >> 10) == 0 {
+        switch (imm12 >> 8) & 0x3 {
+        case 0x0:
+            return imm8, carry_in
+        case 0x1:
+            return (imm8 << 16) | imm8, carry_in
+        case 0x2:
+            return (imm8 << 24) | (imm8 << 8), carry_in
+        default:
+            return (imm8 << 24) | (imm8 << 16) | (imm8 << 8) | imm8, carry_in
+        }
+    }
+
+    unrotated := 0x80 | (imm12 & 0x7f)
+
+    return ROR_C(unrotated, uint8(imm12>>7), carry_in)
+}
+
+/* Carry out of a modified immediate constant.  A rotated constant carries
+ * out its top bit, which is known once the instruction is decoded, while
+ * any other constant carries out the carry flag. */
+type ImmCarry uint8
+
+const (
+    CARRY_IN ImmCarry = iota
+    CARRY_CLEAR
+    CARRY_SET
+)
+
+func (carry ImmCarry) Evaluate(carry_in bool) bool {
+    if carry == CARRY_IN {
+        return carry_in
+    }
+    return carry == CARRY_SET
+}
+
+/* Expand the modified immediate constant of a 32-bit instruction, along
+ * with its carry out
+ * ARM ARM A5.3.2 */
+func ThumbExpandImmCarry(imm12 uint32) (uint32, ImmCarry) {
+    if (imm12 >> 10) == 0 {
+        return ThumbExpandImm(imm12), CARRY_IN
+    }
+
+    imm32, carry := ThumbExpandImm_C(imm12, false)
+    if carry {
+        return imm32, CARRY_SET
+    }
+    return imm32, CARRY_CLEAR
 }
@@ -5,6 +5,7 @@ import (
     "flag"
     "fmt"
     "io"
+    "io/ioutil"
     "os"
 )
 
@@ -21,23 +22,61 @@ func main() {
     }
 
     binary := flag.Arg(0)
-    file, err := os.Open(binary)
+
+    if *execute {
+        run(binary)
+    } else {
+        disassemble(binary)
+    }
+}
+
+/* Execute the binary, starting from its reset vector */
+func run(binary string) {
+    image, err := ioutil.ReadFile(binary)
     if err != nil {
         fmt.Printf("%s\n", err)
         os.Exit(1)
     }
 
-    regs := new(core.Registers)
-    b := make([]byte, 2, 2)
-    addr := 0
-    var upper *core.FetchedInstr16 = nil
+    bus := core.NewBus()
+    if err := bus.Load(core.FLASH_BASE, image); err != nil {
+        fmt.Printf("%s\n", err)
+        os.Exit(1)
+    }
 
-    if *execute {
+    cpu := core.NewCPU(bus)
+    if err := cpu.Reset(); err != nil {
+        fmt.Printf("%s\n", err)
+        os.Exit(1)
+    }
+
+    cpu.Trace = func(addr uint32, fetched core.FetchedInstr, instr core.DecodedInstr) {
         fmt.Printf("Register state:\n")
-        regs.Print()
+        cpu.Regs.Print()
         fmt.Printf("\n")
+        fmt.Printf("%x:\t%v\t%s\t%#v\n", addr, fetched, instr, instr)
+    }
+
+    err = cpu.Run()
+
+    fmt.Printf("Register state:\n")
+    cpu.Regs.Print()
+    fmt.Printf("\n%s\n", err)
+}
+
+/* Decode each instruction in the binary, in order */
+func disassemble(binary string) {
+    file, err := os.Open(binary)
+    if err != nil {
+        fmt.Printf("%s\n", err)
+        os.Exit(1)
     }
 
+    b := make([]byte, 2, 2)
+    addr := 0
+    instr_addr := 0
+    var upper *core.FetchedInstr16 = nil
+
     for {
         n, err := file.Read(b)
         if err == io.EOF {
@@ -60,6 +99,7 @@ func main() {
             upper = nil
         } else {
             fetched = fetched16
+            instr_addr = addr
             fmt.Printf("%x:\t%v", addr, fetched)
         }
 
@@ -74,14 +114,8 @@ func main() {
             continue
         }
 
+        instr = core.Locate(instr, uint32(instr_addr))
         fmt.Printf("\t%s\t%#v\n", instr, instr)
-
-        if *execute {
-            instr.Execute(regs)
-            fmt.Printf("Register state:\n")
-            regs.Print()
-            fmt.Printf("\n")
-        }
     }
 
     file.Close()